@@ -0,0 +1,172 @@
+package gormer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValuesBinder bind params from URL values
+type ValuesBinder interface {
+	FromValues(v url.Values) error
+}
+
+var _ ValuesBinder = &PageParam{}
+var _ ValuesBinder = &OrderParam{}
+
+// FieldError invalid value of a request field
+type FieldError struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// Error implements error
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s(%q): %s", e.Field, e.Value, e.Reason)
+}
+
+// FieldErrors list of FieldError
+type FieldErrors []*FieldError
+
+// Error implements error
+func (es FieldErrors) Error() string {
+	var msg = make([]string, 0, len(es))
+	for _, e := range es {
+		msg = append(msg, e.Error())
+	}
+	return strings.Join(msg, "; ")
+}
+
+// headers fallback for the query string
+var bindHeaders = map[string]string{
+	"X-Page":     "page",
+	"X-Per-Page": "per_page",
+	"X-Sort":     "sort",
+}
+
+// FromRequest bind params from the query string of HTTP request,
+// X-Page, X-Per-Page and X-Sort headers are used while missing in query string
+func FromRequest(r *http.Request, binders ...ValuesBinder) error {
+	values := r.URL.Query()
+	for header, key := range bindHeaders {
+		if _, ok := values[key]; !ok && r.Header.Get(header) != "" {
+			values.Set(key, r.Header.Get(header))
+		}
+	}
+
+	var errs FieldErrors
+	for _, b := range binders {
+		if err := b.FromValues(values); err != nil {
+			fes, ok := err.(FieldErrors)
+			if !ok {
+				return err
+			}
+			errs = append(errs, fes...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// FromValues bind paging params from URL values,
+// supported keys: curr_page/page, page_size/per_page/limit, offset, ignore_page
+func (p *PageParam) FromValues(v url.Values) error {
+	var errs FieldErrors
+
+	parse := func(min int, keys ...string) (n int, ok bool) {
+		for _, key := range keys {
+			s := strings.TrimSpace(v.Get(key))
+			if s == "" {
+				continue
+			}
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				errs = append(errs, &FieldError{Field: key, Value: s, Reason: "must be an integer"})
+				return 0, false
+			}
+			if n < min {
+				errs = append(errs, &FieldError{Field: key, Value: s, Reason: fmt.Sprintf("must be >= %d", min)})
+				return 0, false
+			}
+			return n, true
+		}
+		return 0, false
+	}
+
+	if n, ok := parse(1, "page_size", "per_page", "limit"); ok {
+		p.PageSize = n
+	}
+	if n, ok := parse(1, "curr_page", "page"); ok {
+		p.CurrPage = n
+	}
+	if n, ok := parse(0, "ignore_page"); ok {
+		p.IgnorePage = n
+	}
+
+	// offset must be on the page boundary
+	if n, ok := parse(0, "offset"); ok {
+		p.Init()
+		if n%p.PageSize != 0 {
+			errs = append(errs, &FieldError{
+				Field:  "offset",
+				Value:  v.Get("offset"),
+				Reason: fmt.Sprintf("must be a multiple of page size %d", p.PageSize),
+			})
+		} else {
+			p.CurrPage = n/p.PageSize + 1
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// FromValues bind order params from URL values,
+// supported keys: order_by, order_type, sort(e.g. "-created_at" for DESC), nulls
+func (p *OrderParam) FromValues(v url.Values) error {
+	var errs FieldErrors
+
+	if s := strings.TrimSpace(v.Get("order_by")); s != "" {
+		if !regexpSortField.MatchString(s) {
+			errs = append(errs, &FieldError{Field: "order_by", Value: s, Reason: "must be a column name"})
+		} else {
+			p.OrderBy = s
+		}
+	}
+	if s := strings.TrimSpace(v.Get("order_type")); s != "" {
+		if t := strings.ToUpper(s); t != "ASC" && t != "DESC" {
+			errs = append(errs, &FieldError{Field: "order_type", Value: s, Reason: "must be ASC or DESC"})
+		} else {
+			p.OrderType = t
+		}
+	}
+
+	if s := strings.TrimSpace(v.Get("sort")); s != "" {
+		sorts, err := ParseSorts(s)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: "sort", Value: s, Reason: "must be comma separated column names"})
+		} else {
+			p.Sort = sorts
+		}
+	}
+	if s := strings.TrimSpace(v.Get("nulls")); s != "" {
+		if t := strings.ToUpper(s); t != NullsFirst && t != NullsLast {
+			errs = append(errs, &FieldError{Field: "nulls", Value: s, Reason: "must be FIRST or LAST"})
+		} else {
+			p.Nulls = t
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package gormer
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"errors"
-	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -12,19 +15,59 @@ import (
 // ChunkCallback chunk callback type
 type ChunkCallback func(loop int) error
 
+// ChunkContextCallback chunk callback with the context, carry the ChunkJob
+type ChunkContextCallback func(ctx context.Context, loop int) error
+
 // ErrBreakChunk break the chunk while callback return error
 var ErrBreakChunk = errors.New("break the chunk while")
 
+// ChunkJob name and ID of the chunk run, attached to every log line as fields job and job_id
+type ChunkJob struct {
+	Name string
+	ID   string // (optional) generated if empty
+}
+
+// chunkJobKey context key of ChunkJob
+type chunkJobKey struct{}
+
+// WithChunkJob return the context carry the job, pass it to ChunkByIDMaxMinContext
+func WithChunkJob(ctx context.Context, job ChunkJob) context.Context {
+	return context.WithValue(ctx, chunkJobKey{}, job)
+}
+
+// ChunkJobFromContext the job of the context, e.g. in ChunkContextCallback
+func ChunkJobFromContext(ctx context.Context) (ChunkJob, bool) {
+	job, ok := ctx.Value(chunkJobKey{}).(ChunkJob)
+	return job, ok
+}
+
 // ChunkByIDMaxMin process data in chunks, scope by id
 func ChunkByIDMaxMin(size int64, db *gorm.DB, dest interface{}, callback ChunkCallback, l Logger, extra ...func(db *gorm.DB) *gorm.DB) (err error) {
+	return ChunkByIDMaxMinContext(context.Background(), size, db, dest, func(_ context.Context, loop int) error {
+		return callback(loop)
+	}, l, extra...)
+}
+
+// ChunkByIDMaxMinContext process data in chunks, scope by id,
+// if the ctx carry a ChunkJob by WithChunkJob, the job is attached to every log line,
+// and the ID is generated if empty, the callback can retrieve it by ChunkJobFromContext
+func ChunkByIDMaxMinContext(ctx context.Context, size int64, db *gorm.DB, dest interface{}, callback ChunkContextCallback, l Logger, extra ...func(db *gorm.DB) *gorm.DB) (err error) {
 	if l == nil {
 		l = new(DefaultLogger)
 	}
-	startTime := time.Now().UnixNano()
+	sl := Structured(l)
+	if job, ok := ChunkJobFromContext(ctx); ok {
+		if job.ID == "" {
+			job.ID = newChunkJobID()
+			ctx = WithChunkJob(ctx, job)
+		}
+		sl = WithFields(sl, F("job", job.Name), F("job_id", job.ID))
+	}
+	startTime := time.Now()
 	tableName := TableName(db)
 
 	maxID, minID, err := MaxMinID(db.Scopes(extra...))
-	l.Info(fmt.Sprintf("query result: MinId(%d), MaxId(%d), ERR(%v)", minID, maxID, err))
+	sl.Log(LevelInfo, "chunk max min id", F("min", minID), F("max", maxID), F("err", err))
 	if err != nil {
 		// ignore record not found
 		if gorm.IsRecordNotFoundError(err) {
@@ -56,7 +99,11 @@ func ChunkByIDMaxMin(size int64, db *gorm.DB, dest interface{}, callback ChunkCa
 			Where("? <= id AND id < ?", lastMaxID, lt).
 			Scan(dest)
 
-		l.Info(fmt.Sprintf("No.%d, query result %d <= id < %d, count: %d, err: %v", loop, lastMaxID, lt, res.RowsAffected, res.Error))
+		level := LevelInfo
+		if res.Error != nil {
+			level = LevelWarn
+		}
+		sl.Log(level, "chunk query", F("loop", loop), F("from", lastMaxID), F("to", lt), F("rows", res.RowsAffected), F("err", res.Error))
 
 		lastMaxID += size
 		totalCount += res.RowsAffected
@@ -70,20 +117,28 @@ func ChunkByIDMaxMin(size int64, db *gorm.DB, dest interface{}, callback ChunkCa
 
 		// custom process by callback
 		// if callback return error wrap with ErrBreakChunk, break the while
-		err = callback(loop)
+		err = callback(ctx, loop)
 		if err != nil {
-			l.Error(fmt.Sprintf("No.%d, callback return ---> %v", loop, err))
+			sl.Log(LevelError, "chunk callback", F("loop", loop), F("err", err))
 			if errors.Is(err, ErrBreakChunk) {
 				break
 			}
 		}
 	}
 
-	usedTime := fmt.Sprintf("%.2fms", float64(time.Now().UnixNano()-startTime)/1e6)
-	l.Info(fmt.Sprintf("data processing is completed...Used: %s, TotalCount: %d", usedTime, totalCount))
+	sl.Log(LevelInfo, "chunk completed", F("elapsed", time.Since(startTime)), F("rows", totalCount))
 	return
 }
 
+// newChunkJobID random ID of 8 bytes hex
+func newChunkJobID() string {
+	var b = make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
 // TableName fetch table name from scope
 func TableName(db *gorm.DB) string {
 	if ts, ok := db.Value.(string); ok {
@@ -0,0 +1,128 @@
+package gormer
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec serialization of the column value
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var _ Codec = JSONCodec{}
+var _ Codec = MsgpackCodec{}
+var _ Codec = GobCodec{}
+
+// JSONCodec JSON codec, by encoding/json
+type JSONCodec struct{}
+
+// Marshal implements Codec
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Codec
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// MsgpackCodec MessagePack codec, use the json tag as field name
+type MsgpackCodec struct{}
+
+// Marshal implements Codec
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements Codec
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// GobCodec gob codec, by encoding/gob, the concrete types of interface must be registered by gob.Register
+type GobCodec struct{}
+
+// Marshal implements Codec
+func (GobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements Codec
+func (GobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// CodecScanner decoding DB field to custom type by codec,
+// f must be a non-nil pointer, return ScanError while unsupported source type or invalid payload
+func CodecScanner(c Codec, f interface{}, value interface{}, null ScanNull) error {
+	rv := reflect.ValueOf(f)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return newScanError(f, nil, ErrScanTarget)
+	}
+
+	var payload []byte
+	switch value := value.(type) {
+	case nil:
+		if null == ScanNullZero {
+			rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+		}
+		return nil
+	case []byte:
+		payload = value
+	case string:
+		payload = []byte(value)
+	default:
+		return newScanError(f, []byte(fmt.Sprint(value)), fmt.Errorf("unsupported source type %T", value))
+	}
+
+	if err := c.Unmarshal(payload, f); err != nil {
+		return newScanError(f, payload, err)
+	}
+	return nil
+}
+
+// CodecValuer encoding custom type by codec, the nil pointer and nil interface are encoded as NULL
+func CodecValuer(c Codec, f interface{}) (driver.Value, error) {
+	if isNilValue(f) {
+		return nil, nil
+	}
+	return c.Marshal(f)
+}
+
+// codecDataType the column type of codec by dialect, JSON or binary
+func codecDataType(c Codec, dialect gorm.Dialect) string {
+	if _, ok := c.(JSONCodec); ok {
+		return jsonDataType(dialect)
+	}
+
+	switch dialect.GetName() {
+	case "mysql":
+		return "longblob"
+	case "postgres":
+		return "bytea"
+	case "mssql":
+		return "varbinary(max)"
+	}
+	return "blob"
+}
@@ -0,0 +1,250 @@
+package gormer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// magic prefix of the column payload
+var (
+	magicGzip      = []byte{0x1f, 0x8b}
+	magicZstd      = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicEncrypted = []byte{0x00, 'G', 'E', '1'}
+)
+
+// ErrKeyNotFound the key of id not found
+var ErrKeyNotFound = errors.New("encryption key not found")
+
+// zstd encoder and decoder, safe for concurrent use
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// initZstd init the zstd encoder and decoder once
+func initZstd() error {
+	zstdOnce.Do(func() {
+		if zstdEncoder, zstdErr = zstd.NewWriter(nil); zstdErr == nil {
+			zstdDecoder, zstdErr = zstd.NewReader(nil)
+		}
+	})
+	return zstdErr
+}
+
+// GzipJSONValuer encoding custom type to gzip compressed JSON
+func GzipJSONValuer(f interface{}) (driver.Value, error) {
+	payload, err := jsonPayload(f)
+	if err != nil || payload == nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ZstdJSONValuer encoding custom type to zstd compressed JSON
+func ZstdJSONValuer(f interface{}) (driver.Value, error) {
+	payload, err := jsonPayload(f)
+	if err != nil || payload == nil {
+		return nil, err
+	}
+
+	if err = initZstd(); err != nil {
+		return nil, err
+	}
+
+	return zstdEncoder.EncodeAll(payload, nil), nil
+}
+
+// CompressedJSONScanner decoding DB field(gzip/zstd compressed or plain JSON) to custom type,
+// same as JSONStrictScanner after decompressed
+func CompressedJSONScanner(f interface{}, value interface{}, null ScanNull) error {
+	return EncryptedJSONScanner(nil, f, value, null)
+}
+
+// KeyProvider provide the keys of AES-GCM encryption, support key rotation
+type KeyProvider interface {
+	// CurrentKey the key for encrypting, with the id stored as prefix of payload
+	CurrentKey() (id string, key []byte, err error)
+	// Key the key of id for decrypting
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeys KeyProvider of the fixed keys, encrypt by the key of Current,
+// the key must be 16, 24 or 32 bytes, for AES-128, AES-192 or AES-256
+type StaticKeys struct {
+	Current string
+	Keys    map[string][]byte
+}
+
+var _ KeyProvider = &StaticKeys{}
+
+// CurrentKey the key of Current
+func (k *StaticKeys) CurrentKey() (string, []byte, error) {
+	key, err := k.Key(k.Current)
+	return k.Current, key, err
+}
+
+// Key the key of id
+func (k *StaticKeys) Key(id string) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// EncryptedJSONValuer encrypting JSON of custom type by AES-GCM, with the current key of kp,
+// payload: magic + key id length + key id + nonce + ciphertext
+func EncryptedJSONValuer(kp KeyProvider, f interface{}) (driver.Value, error) {
+	payload, err := jsonPayload(f)
+	if err != nil || payload == nil {
+		return nil, err
+	}
+
+	id, key, err := kp.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(id) > 255 {
+		return nil, fmt.Errorf("key id %q too long", id)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce = make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	var buf = make([]byte, 0, len(magicEncrypted)+1+len(id)+len(nonce)+len(payload)+gcm.Overhead())
+	buf = append(buf, magicEncrypted...)
+	buf = append(buf, byte(len(id)))
+	buf = append(buf, id...)
+	buf = append(buf, nonce...)
+
+	// the key id is authenticated as additional data
+	return gcm.Seal(buf, nonce, payload, []byte(id)), nil
+}
+
+// EncryptedJSONScanner decoding DB field(encrypted, compressed or plain JSON) to custom type,
+// decrypt by the key of id in payload, same as JSONStrictScanner after decrypted and decompressed
+func EncryptedJSONScanner(kp KeyProvider, f interface{}, value interface{}, null ScanNull) error {
+	var payload []byte
+	switch value := value.(type) {
+	case []byte:
+		payload = value
+	case string:
+		payload = []byte(value)
+	default:
+		return JSONStrictScanner(f, value, null)
+	}
+
+	raw := payload
+	payload, err := decodePayload(kp, payload)
+	if err != nil {
+		return newScanError(f, raw, err)
+	}
+
+	// never leak the decrypted payload
+	err = JSONStrictScanner(f, payload, null)
+	var se *ScanError
+	if bytes.HasPrefix(raw, magicEncrypted) && errors.As(err, &se) {
+		se.Preview = "<encrypted>"
+	}
+	return err
+}
+
+// jsonPayload JSON of custom type, nil for NULL
+func jsonPayload(f interface{}) ([]byte, error) {
+	v, err := JSONNullValuer(f)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return []byte(v.(string)), nil
+}
+
+// decodePayload decrypt and decompress the payload, plain JSON returned as is
+func decodePayload(kp KeyProvider, payload []byte) ([]byte, error) {
+	if bytes.HasPrefix(payload, magicEncrypted) {
+		if kp == nil {
+			return payload, errors.New("encrypted payload without key provider")
+		}
+		var err error
+		if payload, err = decrypt(kp, payload[len(magicEncrypted):]); err != nil {
+			return payload, err
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(payload, magicGzip):
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return payload, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case bytes.HasPrefix(payload, magicZstd):
+		if err := initZstd(); err != nil {
+			return payload, err
+		}
+		return zstdDecoder.DecodeAll(payload, nil)
+	}
+
+	return payload, nil
+}
+
+// decrypt the payload after magic prefix
+func decrypt(kp KeyProvider, payload []byte) ([]byte, error) {
+	if len(payload) < 1 || len(payload) < 1+int(payload[0]) {
+		return nil, errors.New("invalid encrypted payload")
+	}
+	id := string(payload[1 : 1+int(payload[0])])
+	payload = payload[1+int(payload[0]):]
+
+	key, err := kp.Key(id)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted payload")
+	}
+
+	return gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], []byte(id))
+}
+
+// newGCM AES-GCM cipher of key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,220 @@
+package gormer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DefaultSeparator default separator of delimited column, same as MySQL SET
+const DefaultSeparator = ","
+
+// Int64s []int64 column stored as delimited string, e.g. "1,5,9",
+// NULL is scanned as nil, empty string as empty slice, nil is stored as empty string
+type Int64s []int64
+
+var _ sql.Scanner = &Int64s{}
+var _ driver.Valuer = Int64s{}
+
+// Scan implements sql.Scanner
+func (l *Int64s) Scan(value interface{}) error {
+	return DelimitedScanner(l, value, DefaultSeparator, ScanNullZero)
+}
+
+// Value implements driver.Valuer
+func (l Int64s) Value() (driver.Value, error) {
+	return DelimitedValuer(l, DefaultSeparator)
+}
+
+// GormDataType the data type of column, by dialect
+func (Int64s) GormDataType(dialect gorm.Dialect) string {
+	return textDataType(dialect)
+}
+
+// Strings []string column stored as delimited string, e.g. MySQL SET "a,b,c",
+// NULL is scanned as nil, empty string as empty slice, nil is stored as empty string
+type Strings []string
+
+var _ sql.Scanner = &Strings{}
+var _ driver.Valuer = Strings{}
+
+// Scan implements sql.Scanner
+func (l *Strings) Scan(value interface{}) error {
+	return DelimitedScanner(l, value, DefaultSeparator, ScanNullZero)
+}
+
+// Value implements driver.Valuer
+func (l Strings) Value() (driver.Value, error) {
+	return DelimitedValuer(l, DefaultSeparator)
+}
+
+// GormDataType the data type of column, by dialect
+func (Strings) GormDataType(dialect gorm.Dialect) string {
+	return textDataType(dialect)
+}
+
+// DelimitedScanner decoding DB field(delimited string) to slice of integer or string,
+// f must be a pointer to slice, e.g. *[]int64, *[]string, *[]enum.PayType,
+// empty string is decoded as empty slice, NULL handled by null
+func DelimitedScanner(f interface{}, value interface{}, sep string, null ScanNull) error {
+	rv := reflect.ValueOf(f)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return newScanError(f, nil, fmt.Errorf("scan target must be a pointer to slice"))
+	}
+	slice := rv.Elem()
+
+	var payload string
+	switch value := value.(type) {
+	case nil:
+		if null == ScanNullZero {
+			slice.Set(reflect.Zero(slice.Type()))
+		}
+		return nil
+	case []byte:
+		payload = string(value)
+	case string:
+		payload = value
+	default:
+		return newScanError(f, []byte(fmt.Sprint(value)), fmt.Errorf("unsupported source type %T", value))
+	}
+
+	var items []string
+	if strings.TrimSpace(payload) != "" {
+		items = strings.Split(payload, sep)
+	}
+
+	list := reflect.MakeSlice(slice.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := setDelimitedItem(list.Index(i), strings.TrimSpace(item)); err != nil {
+			return newScanError(f, []byte(payload), err)
+		}
+	}
+	slice.Set(list)
+
+	return nil
+}
+
+// DelimitedValuer encoding slice of integer or string to delimited string, nil is encoded as empty string
+func DelimitedValuer(f interface{}, sep string) (driver.Value, error) {
+	rv := reflect.Indirect(reflect.ValueOf(f))
+	if !rv.IsValid() {
+		return "", nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("unsupported delimited type %T", f)
+	}
+
+	var items = make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item, err := delimitedItem(rv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(item, sep) {
+			return nil, fmt.Errorf("item %q contains the separator %q", item, sep)
+		}
+		items = append(items, item)
+	}
+
+	return strings.Join(items, sep), nil
+}
+
+// DelimitedNullValuer encoding slice of integer or string to delimited string, nil is encoded as NULL
+func DelimitedNullValuer(f interface{}, sep string) (driver.Value, error) {
+	rv := reflect.Indirect(reflect.ValueOf(f))
+	if !rv.IsValid() || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+		return nil, nil
+	}
+	return DelimitedValuer(f, sep)
+}
+
+// setDelimitedItem parse the item by the kind of v
+func setDelimitedItem(v reflect.Value, item string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(item)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(item, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(item, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported delimited item type %s", v.Type())
+	}
+	return nil
+}
+
+// delimitedItem format the item by the kind of v
+func delimitedItem(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported delimited item type %s", v.Type())
+}
+
+// FindInSet scope: WHERE the comma delimited column contains any of values,
+// FIND_IN_SET() on MySQL, LIKE on the others
+func FindInSet(column string, values ...interface{}) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(values) == 0 {
+			return db
+		}
+
+		col := column
+		if regexpSortField.MatchString(col) {
+			col, _ = quoteColumn(db.Dialect().Quote)(col)
+		}
+
+		var conds []string
+		var args []interface{}
+		for _, v := range values {
+			switch db.Dialect().GetName() {
+			case "mysql":
+				conds = append(conds, fmt.Sprintf("FIND_IN_SET(?, %s) > 0", col))
+				args = append(args, v)
+			case "mssql":
+				conds = append(conds, fmt.Sprintf("(',' + %s + ',') LIKE ? ESCAPE '\\'", col))
+				args = append(args, "%,"+escapeLike(fmt.Sprint(v), "[")+",%")
+			default:
+				conds = append(conds, fmt.Sprintf("(',' || %s || ',') LIKE ? ESCAPE '\\'", col))
+				args = append(args, "%,"+escapeLike(fmt.Sprint(v))+",%")
+			}
+		}
+
+		return db.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+}
+
+// escapeLike escape the wildcards of LIKE pattern by backslash, with the extra special characters of dialect
+func escapeLike(s string, special ...string) string {
+	var oldnew = []string{`\`, `\\`, "%", `\%`, "_", `\_`}
+	for _, c := range special {
+		oldnew = append(oldnew, c, `\`+c)
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// textDataType the text column type of dialect
+func textDataType(dialect gorm.Dialect) string {
+	if dialect.GetName() == "mssql" {
+		return "nvarchar(max)"
+	}
+	return "text"
+}
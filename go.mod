@@ -1,8 +1,17 @@
 module github.com/shockerli/gormer
 
-go 1.13
+go 1.18
 
 require (
 	github.com/blastrain/vitess-sqlparser v0.0.0-20201030050434-a139afbb1aba
 	github.com/jinzhu/gorm v1.9.16
+	github.com/klauspost/compress v1.11.13
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/juju/errors v0.0.0-20170703010042-c7d06af17c68 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/text v0.3.0 // indirect
 )
@@ -0,0 +1,72 @@
+package gormer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// pagination response headers
+const (
+	HeaderLink            = "Link"
+	HeaderTotalCount      = "X-Total-Count"
+	HeaderTotalIsEstimate = "X-Total-Is-Estimate"
+	HeaderPage            = "X-Page"
+	HeaderPerPage         = "X-Per-Page"
+)
+
+// WriteHeaders write the pagination headers, GitHub style:
+// Link(RFC 5988) of first/prev/next/last page, X-Total-Count, X-Page and X-Per-Page,
+// u is the request URL, the paging params of query string are replaced by page and per_page
+func (p *PageResult) WriteHeaders(w http.ResponseWriter, u *url.URL) {
+	h := w.Header()
+
+	if p.CountStrategy != CountNone || p.IgnorePage != 0 {
+		h.Set(HeaderTotalCount, strconv.Itoa(p.TotalCount))
+	}
+	if p.TotalIsEstimate {
+		h.Set(HeaderTotalIsEstimate, "true")
+	}
+
+	// no paging
+	if p.IgnorePage != 0 {
+		return
+	}
+
+	h.Set(HeaderPage, strconv.Itoa(p.CurrPage))
+	h.Set(HeaderPerPage, strconv.Itoa(p.PageSize))
+
+	var links []string
+	link := func(page int, rel string) {
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, p.pageURL(u, page), rel))
+	}
+	link(1, "first")
+	if p.HasPrev {
+		link(p.PrevPage, "prev")
+	}
+	if p.HasNext {
+		link(p.NextPage, "next")
+	}
+	// the last page is unknown while total is not exact
+	if !p.TotalIsEstimate && p.TotalPages > 0 {
+		link(p.TotalPages, "last")
+	}
+
+	h.Set(HeaderLink, strings.Join(links, ", "))
+}
+
+// pageURL URL of the page, keep the other query params
+func (p *PageResult) pageURL(u *url.URL, page int) string {
+	var pu = *u
+	query := pu.Query()
+	for _, key := range []string{"curr_page", "page_size", "limit", "offset"} {
+		query.Del(key)
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(p.PageSize))
+	pu.RawQuery = query.Encode()
+
+	return pu.String()
+}
@@ -0,0 +1,116 @@
+package gormer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+var regexpJSONPath, _ = regexp.Compile(`^\$(\.[A-Za-z_][A-Za-z0-9_]*|\[\d+\])*$`)
+var regexpJSONPathSegment, _ = regexp.Compile(`\.([A-Za-z_][A-Za-z0-9_]*)|\[(\d+)\]`)
+
+// ErrInvalidJSONPath invalid JSON path, only support $, .key and [index]
+var ErrInvalidJSONPath = errors.New("invalid JSON path")
+
+// ErrUnsupportedDialect the dialect is not supported
+var ErrUnsupportedDialect = errors.New("unsupported dialect")
+
+// JSONExtractEq scope: WHERE the value at path of JSON column equals to value, e.g. "$.a.b[0]",
+// JSON_EXTRACT() on MySQL, #>> on PostgreSQL(compared as text), json_extract() on SQLite
+func JSONExtractEq(column, path string, value interface{}) func(db *gorm.DB) *gorm.DB {
+	return jsonScope(column, path, func(dialect, col string, segments []string) (string, []interface{}, error) {
+		switch dialect {
+		case "mysql":
+			return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?", col), []interface{}{path, value}, nil
+		case "postgres":
+			return fmt.Sprintf("(%s::jsonb #>> ?::text[]) = ?", col), []interface{}{pgPath(segments), fmt.Sprint(value)}, nil
+		case "sqlite3":
+			return fmt.Sprintf("json_extract(%s, ?) = ?", col), []interface{}{path, value}, nil
+		}
+		return "", nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	})
+}
+
+// JSONContains scope: WHERE the value at path of JSON column contains value,
+// the value is encoded to JSON as candidate, object or array is not supported on SQLite,
+// JSON_CONTAINS() on MySQL, @> on PostgreSQL, json_each() on SQLite
+func JSONContains(column, path string, value interface{}) func(db *gorm.DB) *gorm.DB {
+	return jsonScope(column, path, func(dialect, col string, segments []string) (string, []interface{}, error) {
+		candidate, err := json.Marshal(value)
+		if err != nil {
+			return "", nil, err
+		}
+
+		switch dialect {
+		case "mysql":
+			return fmt.Sprintf("JSON_CONTAINS(%s, ?, ?)", col), []interface{}{string(candidate), path}, nil
+		case "postgres":
+			return fmt.Sprintf("(%s::jsonb #> ?::text[]) @> ?::jsonb", col), []interface{}{pgPath(segments), string(candidate)}, nil
+		case "sqlite3":
+			if c := strings.TrimSpace(string(candidate)); strings.HasPrefix(c, "{") || strings.HasPrefix(c, "[") {
+				return "", nil, fmt.Errorf("%w: object or array candidate on %s", ErrUnsupportedDialect, dialect)
+			}
+			return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, ?) WHERE json_each.value = ?)", col), []interface{}{path, value}, nil
+		}
+		return "", nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	})
+}
+
+// JSONHasKey scope: WHERE the path exists in JSON column,
+// JSON_CONTAINS_PATH() on MySQL, #> on PostgreSQL, json_type() on SQLite
+func JSONHasKey(column, path string) func(db *gorm.DB) *gorm.DB {
+	return jsonScope(column, path, func(dialect, col string, segments []string) (string, []interface{}, error) {
+		switch dialect {
+		case "mysql":
+			return fmt.Sprintf("JSON_CONTAINS_PATH(%s, 'one', ?)", col), []interface{}{path}, nil
+		case "postgres":
+			return fmt.Sprintf("(%s::jsonb #> ?::text[]) IS NOT NULL", col), []interface{}{pgPath(segments)}, nil
+		case "sqlite3":
+			return fmt.Sprintf("json_type(%s, ?) IS NOT NULL", col), []interface{}{path}, nil
+		}
+		return "", nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	})
+}
+
+// jsonScope validate the path, quote the column, build condition by dialect
+func jsonScope(column, path string, build func(dialect, col string, segments []string) (string, []interface{}, error)) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		segments, err := ParseJSONPath(path)
+		if err != nil {
+			return scopeError(db, err)
+		}
+
+		col := column
+		if regexpSortField.MatchString(col) {
+			col, _ = quoteColumn(db.Dialect().Quote)(col)
+		}
+
+		query, args, err := build(db.Dialect().GetName(), col, segments)
+		if err != nil {
+			return scopeError(db, err)
+		}
+		return db.Where(query, args...)
+	}
+}
+
+// ParseJSONPath validate the JSON path and return the keys and indexes, e.g. "$.a[0]" -> ["a", "0"]
+func ParseJSONPath(path string) ([]string, error) {
+	if !regexpJSONPath.MatchString(path) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidJSONPath, path)
+	}
+
+	var segments = []string{}
+	for _, m := range regexpJSONPathSegment.FindAllStringSubmatch(path, -1) {
+		segments = append(segments, m[1]+m[2])
+	}
+	return segments, nil
+}
+
+// pgPath PostgreSQL text array of path
+func pgPath(segments []string) string {
+	return "{" + strings.Join(segments, ",") + "}"
+}
@@ -1,6 +1,14 @@
 package gormer
 
-import "log"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
 
 // Logger log message
 type Logger interface {
@@ -9,10 +17,161 @@ type Logger interface {
 	Error(...interface{})
 }
 
+// Level log level
+type Level int8
+
+// log levels
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String name of level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", l)
+}
+
+// Field key/value of structured log
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F new a field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// StructuredLogger leveled logger with key/value fields
+type StructuredLogger interface {
+	Log(level Level, msg string, fields ...Field)
+}
+
+// LevelLogger Logger with Warn, and StructuredLogger
+type LevelLogger interface {
+	Logger
+	StructuredLogger
+	Warn(...interface{})
+}
+
+// Leveled wrap the StructuredLogger as LevelLogger, use it as Logger
+func Leveled(l StructuredLogger) LevelLogger {
+	if ll, ok := l.(LevelLogger); ok {
+		return ll
+	}
+	return &leveled{StructuredLogger: l}
+}
+
+// leveled LevelLogger by the StructuredLogger
+type leveled struct {
+	StructuredLogger
+}
+
+// Debug log a debug message
+func (l *leveled) Debug(v ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprint(v...))
+}
+
+// Info log a message
+func (l *leveled) Info(v ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprint(v...))
+}
+
+// Warn log a warning message
+func (l *leveled) Warn(v ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprint(v...))
+}
+
+// Error log a error message
+func (l *leveled) Error(v ...interface{}) {
+	l.Log(LevelError, fmt.Sprint(v...))
+}
+
+// Structured wrap the Logger as StructuredLogger,
+// the fields are formatted as "msg key=value ...", Warn is logged by Info if not implemented
+func Structured(l Logger) StructuredLogger {
+	if sl, ok := l.(StructuredLogger); ok {
+		return sl
+	}
+	return &structuredShim{l: l}
+}
+
+// structuredShim StructuredLogger by the Logger
+type structuredShim struct {
+	l Logger
+}
+
+// Log implements StructuredLogger
+func (s *structuredShim) Log(level Level, msg string, fields ...Field) {
+	line := formatFields(msg, fields)
+	switch level {
+	case LevelDebug:
+		s.l.Debug(line)
+	case LevelInfo:
+		s.l.Info(line)
+	case LevelWarn:
+		if w, ok := s.l.(interface{ Warn(...interface{}) }); ok {
+			w.Warn(line)
+		} else {
+			s.l.Info(line)
+		}
+	default:
+		s.l.Error(line)
+	}
+}
+
+// WithFields prepend the fields to every message, e.g. the job name of chunk
+func WithFields(l StructuredLogger, fields ...Field) StructuredLogger {
+	return &withFields{l: l, fields: fields}
+}
+
+// withFields logger with the fixed fields
+type withFields struct {
+	l      StructuredLogger
+	fields []Field
+}
+
+// Log implements StructuredLogger
+func (w *withFields) Log(level Level, msg string, fields ...Field) {
+	all := make([]Field, 0, len(w.fields)+len(fields))
+	all = append(all, w.fields...)
+	w.l.Log(level, msg, append(all, fields...)...)
+}
+
+// LevelFilter only log the message which level >= min
+func LevelFilter(l StructuredLogger, min Level) LevelLogger {
+	return Leveled(&levelFilter{l: l, min: min})
+}
+
+// levelFilter filter by level
+type levelFilter struct {
+	l   StructuredLogger
+	min Level
+}
+
+// Log implements StructuredLogger
+func (f *levelFilter) Log(level Level, msg string, fields ...Field) {
+	if level >= f.min {
+		f.l.Log(level, msg, fields...)
+	}
+}
+
 // DefaultLogger default logger, console output
 type DefaultLogger struct{}
 
-var _ Logger = &DefaultLogger{}
+var _ LevelLogger = &DefaultLogger{}
 
 // Debug log a debug message
 func (*DefaultLogger) Debug(v ...interface{}) {
@@ -24,15 +183,25 @@ func (*DefaultLogger) Info(v ...interface{}) {
 	log.Println(v...)
 }
 
+// Warn log a warning message
+func (*DefaultLogger) Warn(v ...interface{}) {
+	log.Println(v...)
+}
+
 // Error log a error message
 func (*DefaultLogger) Error(v ...interface{}) {
 	log.Println(v...)
 }
 
+// Log log a message with fields
+func (*DefaultLogger) Log(_ Level, msg string, fields ...Field) {
+	log.Println(formatFields(msg, fields))
+}
+
 // NoLogger no log to output
 type NoLogger struct{}
 
-var _ Logger = &NoLogger{}
+var _ LevelLogger = &NoLogger{}
 
 // Debug log a debug message
 func (*NoLogger) Debug(...interface{}) {}
@@ -40,5 +209,142 @@ func (*NoLogger) Debug(...interface{}) {}
 // Info log a message
 func (*NoLogger) Info(...interface{}) {}
 
+// Warn log a warning message
+func (*NoLogger) Warn(...interface{}) {}
+
 // Error log a error message
 func (*NoLogger) Error(...interface{}) {}
+
+// Log log a message with fields
+func (*NoLogger) Log(Level, string, ...Field) {}
+
+// StdLogger leveled logger by the standard log package, output "[level] msg key=value ..."
+type StdLogger struct {
+	Logger *log.Logger // (optional) default the standard logger
+	Level  Level       // (optional) min level, default LevelDebug
+}
+
+var _ LevelLogger = &StdLogger{}
+
+// Debug log a debug message
+func (l *StdLogger) Debug(v ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprint(v...))
+}
+
+// Info log a message
+func (l *StdLogger) Info(v ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprint(v...))
+}
+
+// Warn log a warning message
+func (l *StdLogger) Warn(v ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprint(v...))
+}
+
+// Error log a error message
+func (l *StdLogger) Error(v ...interface{}) {
+	l.Log(LevelError, fmt.Sprint(v...))
+}
+
+// Log log a message with fields
+func (l *StdLogger) Log(level Level, msg string, fields ...Field) {
+	if level < l.Level {
+		return
+	}
+
+	line := "[" + strings.ToUpper(level.String()) + "] " + formatFields(msg, fields)
+	if l.Logger == nil {
+		log.Println(line)
+		return
+	}
+	l.Logger.Println(line)
+}
+
+// JSONLogger leveled logger output JSON lines,
+// e.g. {"time":"2006-01-02T15:04:05Z07:00","level":"info","msg":"...","key":"value"}
+type JSONLogger struct {
+	Writer io.Writer
+	Level  Level // (optional) min level, default LevelDebug
+
+	mu sync.Mutex
+}
+
+var _ LevelLogger = &JSONLogger{}
+
+// NewJSONLogger new JSONLogger write to w
+func NewJSONLogger(w io.Writer, level Level) *JSONLogger {
+	return &JSONLogger{Writer: w, Level: level}
+}
+
+// Debug log a debug message
+func (l *JSONLogger) Debug(v ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprint(v...))
+}
+
+// Info log a message
+func (l *JSONLogger) Info(v ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprint(v...))
+}
+
+// Warn log a warning message
+func (l *JSONLogger) Warn(v ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprint(v...))
+}
+
+// Error log a error message
+func (l *JSONLogger) Error(v ...interface{}) {
+	l.Log(LevelError, fmt.Sprint(v...))
+}
+
+// Log log a message with fields
+func (l *JSONLogger) Log(level Level, msg string, fields ...Field) {
+	if level < l.Level {
+		return
+	}
+
+	var entry = make(map[string]interface{}, len(fields)+3)
+	for _, f := range fields {
+		entry[f.Key] = fieldValue(f.Value)
+	}
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		line, _ = json.Marshal(map[string]interface{}{
+			"time":  entry["time"],
+			"level": entry["level"],
+			"msg":   msg,
+			"error": err.Error(),
+		})
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.Writer.Write(append(line, '\n'))
+}
+
+// formatFields format as "msg key=value ..."
+func formatFields(msg string, fields []Field) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, f := range fields {
+		b.WriteString(" ")
+		b.WriteString(f.Key)
+		b.WriteString("=")
+		b.WriteString(fmt.Sprint(fieldValue(f.Value)))
+	}
+	return b.String()
+}
+
+// fieldValue readable value of field, error as message, duration as string
+func fieldValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case time.Duration:
+		return v.String()
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+//go:build go1.21
+// +build go1.21
+
+package gormer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SlogLogger gormer Logger by log/slog Handler
+type SlogLogger struct {
+	Handler slog.Handler
+}
+
+var _ LevelLogger = &SlogLogger{}
+
+// NewSlogLogger new gormer Logger by log/slog Handler, default slog.Default().Handler()
+func NewSlogLogger(h slog.Handler) *SlogLogger {
+	if h == nil {
+		h = slog.Default().Handler()
+	}
+	return &SlogLogger{Handler: h}
+}
+
+// Debug log a debug message
+func (l *SlogLogger) Debug(v ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprint(v...))
+}
+
+// Info log a message
+func (l *SlogLogger) Info(v ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprint(v...))
+}
+
+// Warn log a warning message
+func (l *SlogLogger) Warn(v ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprint(v...))
+}
+
+// Error log a error message
+func (l *SlogLogger) Error(v ...interface{}) {
+	l.Log(LevelError, fmt.Sprint(v...))
+}
+
+// Log log a message with fields
+func (l *SlogLogger) Log(level Level, msg string, fields ...Field) {
+	ctx := context.Background()
+	sl := slogLevel(level)
+	if !l.Handler.Enabled(ctx, sl) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), sl, msg, 0)
+	for _, f := range fields {
+		r.AddAttrs(slog.Any(f.Key, fieldValue(f.Value)))
+	}
+	_ = l.Handler.Handle(ctx, r)
+}
+
+// slogLevel the slog level of level
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
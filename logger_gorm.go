@@ -0,0 +1,60 @@
+package gormer
+
+import "fmt"
+
+// GormLogger GORM SQL logger by gormer Logger,
+// usage: db.SetLogger(gormer.NewGormLogger(l)); db.LogMode(true)
+type GormLogger struct {
+	Logger   StructuredLogger
+	SQLLevel Level // (optional) level of SQL log, default LevelDebug
+}
+
+// NewGormLogger new GORM logger by gormer Logger
+func NewGormLogger(l Logger) *GormLogger {
+	if l == nil {
+		l = new(DefaultLogger)
+	}
+	return &GormLogger{Logger: Structured(l), SQLLevel: LevelDebug}
+}
+
+// Print implements the logger of gorm.DB.SetLogger,
+// values: ("sql", caller, duration, sql, vars, rows), ("error", caller, err) or ("log", caller, messages...)
+func (g *GormLogger) Print(values ...interface{}) {
+	if len(values) < 2 {
+		g.Logger.Log(LevelInfo, fmt.Sprint(values...))
+		return
+	}
+
+	caller := F("caller", values[1])
+	switch values[0] {
+	case "sql":
+		if len(values) < 6 {
+			break
+		}
+		g.Logger.Log(g.SQLLevel, "sql",
+			F("sql", values[3]),
+			F("vars", values[4]),
+			F("elapsed", values[2]),
+			F("rows", values[5]),
+			caller,
+		)
+		return
+	case "error":
+		if len(values) < 3 {
+			break
+		}
+		g.Logger.Log(LevelError, "gorm error", F("err", values[2]), caller)
+		return
+	case "log":
+		for _, v := range values[2:] {
+			if err, ok := v.(error); ok {
+				g.Logger.Log(LevelError, "gorm error", F("err", err), caller)
+				return
+			}
+		}
+		g.Logger.Log(LevelInfo, fmt.Sprint(values[2:]...), caller)
+		return
+	}
+
+	g.Logger.Log(LevelInfo, fmt.Sprint(values[2:]...), caller)
+}
@@ -1,21 +1,49 @@
 package gormer
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/jinzhu/gorm"
 )
 
+// order directions
+const (
+	OrderASC  = "ASC"
+	OrderDESC = "DESC"
+)
+
+// null placements
+const (
+	NullsFirst = "FIRST"
+	NullsLast  = "LAST"
+)
+
+var regexpSortField, _ = regexp.Compile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
 // OrderParam params of order by statement
 type OrderParam struct {
-	OrderBy   string `json:"order_by"`   // (optional) column name, or SQL ORDER statement
-	OrderType string `json:"order_type"` // (optional) ASC/DESC
+	OrderBy     string `json:"order_by"`        // (optional) column name, or SQL ORDER statement
+	OrderType   string `json:"order_type"`      // (optional) ASC/DESC
+	Sort        Sorts  `json:"sort,omitempty"`  // (optional) multiple columns, e.g. "-created_at,name", prior to OrderBy
+	Nulls       string `json:"nulls,omitempty"` // (optional) FIRST/LAST, null placement of the columns
+	DefaultSort Sorts  `json:"-"`               // (optional) sort while no order given
+	Stable      bool   `json:"-"`               // (optional) append the unique Tiebreaker to ORDER BY, deterministic paging
+	Tiebreaker  string `json:"-"`               // (optional) unique column for Stable, default the primary key
 }
 
-// Order scope: order by
+// Order scope: order by, OrderBy is used as is, use SafeOrder for the client input
 func (p *OrderParam) Order() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if p != nil && p.OrderBy != "" {
+		if p == nil {
+			return db
+		}
+
+		// raw ORDER statement
+		if len(p.Sort) == 0 && p.Nulls == "" && p.OrderBy != "" {
 			order := p.OrderBy
 			if strings.ToUpper(p.OrderType) == "DESC" &&
 				!strings.Contains(p.OrderBy, ",") &&
@@ -24,8 +52,362 @@ func (p *OrderParam) Order() func(db *gorm.DB) *gorm.DB {
 
 				order += " DESC"
 			}
+			if tb := p.tiebreaker(db); tb != "" {
+				if sorts, err := p.Sorts(); err != nil || !sorts.has(tb) {
+					col, _ := quoteColumn(db.Dialect().Quote)(tb)
+					order += ", " + col
+				}
+			}
 			return db.Order(order)
 		}
+
+		return p.order(db, quoteColumn(db.Dialect().Quote))
+	}
+}
+
+// scopeError record the error of scope on a clone of db,
+// the Scopes applies on the caller's handle, the error would break the later queries of a shared DB
+func scopeError(db *gorm.DB, err error) *gorm.DB {
+	db = db.Set("gormer:scope_error", err)
+	_ = db.AddError(err)
+	return db
+}
+
+// order apply ORDER statement, the column of client sort field resolved by column,
+// DefaultSort and Tiebreaker are trusted
+func (p *OrderParam) order(db *gorm.DB, column func(field string) (string, error)) *gorm.DB {
+	dialect, trusted := db.Dialect().GetName(), quoteColumn(db.Dialect().Quote)
+
+	sorts, err := p.Sorts()
+	if err != nil {
+		return scopeError(db, err)
+	}
+	if len(sorts) == 0 {
+		sorts, column = p.DefaultSort, trusted
+	}
+
+	var orders []string
+	if len(sorts) > 0 {
+		order, err := sorts.orderBy(dialect, p.Nulls, column)
+		if err != nil {
+			return scopeError(db, err)
+		}
+		orders = append(orders, order)
+	}
+	if tb := p.tiebreaker(db); tb != "" && !sorts.has(tb) {
+		order, _ := Sorts{{Field: tb}}.orderBy(dialect, "", trusted)
+		orders = append(orders, order)
+	}
+
+	if len(orders) == 0 {
 		return db
 	}
+	return db.Order(strings.Join(orders, ", "))
+}
+
+// tiebreaker the unique column while Stable, Tiebreaker or primary key
+func (p *OrderParam) tiebreaker(db *gorm.DB) string {
+	if !p.Stable {
+		return ""
+	}
+	if p.Tiebreaker != "" {
+		return p.Tiebreaker
+	}
+	if pk := db.NewScope(db.Value).PrimaryKey(); pk != "" {
+		return pk
+	}
+	return "id"
+}
+
+// Sorts the sort columns of Sort, or parsed from OrderBy("key [ASC|DESC], ...") and OrderType
+func (p *OrderParam) Sorts() (Sorts, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if len(p.Sort) > 0 {
+		return p.Sort, nil
+	}
+	if strings.TrimSpace(p.OrderBy) == "" {
+		return nil, nil
+	}
+
+	orderType := strings.ToUpper(strings.TrimSpace(p.OrderType))
+	if orderType != "" && orderType != OrderASC && orderType != OrderDESC {
+		return nil, fmt.Errorf("%w: order type %q", ErrInvalidOrder, p.OrderType)
+	}
+
+	var sorts Sorts
+	for _, item := range strings.Split(p.OrderBy, ",") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidOrder, item)
+		}
+
+		direction := orderType
+		if len(fields) == 2 {
+			direction = strings.ToUpper(fields[1])
+			if direction != OrderASC && direction != OrderDESC {
+				return nil, fmt.Errorf("%w: order type %q", ErrInvalidOrder, fields[1])
+			}
+		}
+		if direction == "" {
+			direction = OrderASC
+		}
+
+		sorts = append(sorts, SortField{Field: fields[0], Direction: direction})
+	}
+
+	return sorts, nil
+}
+
+// ErrInvalidOrder invalid order params
+var ErrInvalidOrder = errors.New("invalid order")
+
+// OrderKeyError the sort key is not allowed
+type OrderKeyError struct {
+	Key string
+}
+
+// Error implements error
+func (e *OrderKeyError) Error() string {
+	return fmt.Sprintf("invalid order: sort key %q is not allowed", e.Key)
+}
+
+// Unwrap support errors.Is(err, ErrInvalidOrder)
+func (e *OrderKeyError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// OrderColumns allowed sort keys, mapped to the column name or expression,
+// column names are quoted by dialect, the others are used as is
+type OrderColumns map[string]string
+
+// column quoted column name or raw expression of the key
+func (c OrderColumns) column(quote func(string) string, key string) (string, error) {
+	col, ok := c[key]
+	if !ok {
+		return "", &OrderKeyError{Key: key}
+	}
+	if col == "" {
+		col = key
+	}
+	if quote == nil || !regexpSortField.MatchString(col) {
+		return col, nil
+	}
+	return quoteColumn(quote)(col)
+}
+
+// SafeOrder scope: order by the allowed sort keys only,
+// error OrderKeyError added to db while the key is unknown
+func (p *OrderParam) SafeOrder(columns OrderColumns) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if p == nil {
+			return db
+		}
+
+		quote := db.Dialect().Quote
+		return p.order(db, func(field string) (string, error) {
+			return columns.column(quote, field)
+		})
+	}
+}
+
+// Validate check the sort keys are allowed
+func (p *OrderParam) Validate(columns OrderColumns) error {
+	if p == nil {
+		return nil
+	}
+
+	sorts, err := p.Sorts()
+	if err != nil {
+		return err
+	}
+
+	_, err = sorts.orderBy("", p.Nulls, func(field string) (string, error) {
+		return columns.column(nil, field)
+	})
+	return err
+}
+
+// SortField sort column and direction
+type SortField struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`       // ASC/DESC, default ASC
+	Nulls     string `json:"nulls,omitempty"` // FIRST/LAST, default by OrderParam.Nulls
+}
+
+// Sorts multiple sort columns
+type Sorts []SortField
+
+// ParseSorts parse sort columns from string, e.g. "-created_at,name",
+// the prefix "-" means DESC, "+" or no prefix means ASC
+func ParseSorts(s string) (Sorts, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var sorts Sorts
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		var direction = OrderASC
+		if strings.HasPrefix(f, "-") {
+			f, direction = f[1:], OrderDESC
+		} else if strings.HasPrefix(f, "+") {
+			f = f[1:]
+		}
+		if !regexpSortField.MatchString(f) {
+			return nil, fmt.Errorf("%w: sort field %q", ErrInvalidOrder, f)
+		}
+		sorts = append(sorts, SortField{Field: f, Direction: direction})
+	}
+	return sorts, nil
+}
+
+// String format as "-created_at,name"
+func (s Sorts) String() string {
+	var fields = make([]string, 0, len(s))
+	for _, f := range s {
+		if strings.ToUpper(f.Direction) == OrderDESC {
+			fields = append(fields, "-"+f.Field)
+		} else {
+			fields = append(fields, f.Field)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// UnmarshalJSON decode from string "-created_at,name" or list of SortField
+func (s *Sorts) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		sorts, err := ParseSorts(str)
+		if err != nil {
+			return err
+		}
+		*s = sorts
+		return nil
+	}
+
+	var fields []SortField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for i, f := range fields {
+		if !regexpSortField.MatchString(f.Field) {
+			return fmt.Errorf("%w: sort field %q", ErrInvalidOrder, f.Field)
+		}
+		switch strings.ToUpper(f.Direction) {
+		case "", OrderASC:
+			fields[i].Direction = OrderASC
+		case OrderDESC:
+			fields[i].Direction = OrderDESC
+		default:
+			return fmt.Errorf("%w: order type %q", ErrInvalidOrder, f.Direction)
+		}
+		switch fields[i].Nulls = strings.ToUpper(f.Nulls); fields[i].Nulls {
+		case "", NullsFirst, NullsLast:
+		default:
+			return fmt.Errorf("%w: nulls %q", ErrInvalidOrder, f.Nulls)
+		}
+	}
+	*s = fields
+	return nil
+}
+
+// has whether the field is sorted
+func (s Sorts) has(field string) bool {
+	for _, f := range s {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// orderBy build ORDER statement, with the column of field resolved,
+// null placement is native on PostgreSQL and SQLite, emulated on MySQL and the others
+func (s Sorts) orderBy(dialect, nulls string, column func(field string) (string, error)) (string, error) {
+	var orders = make([]string, 0, len(s))
+	for _, f := range s {
+		col, err := column(f.Field)
+		if err != nil {
+			return "", err
+		}
+
+		direction := OrderASC
+		if strings.ToUpper(f.Direction) == OrderDESC {
+			direction = OrderDESC
+		}
+		placement := strings.ToUpper(f.Nulls)
+		if placement == "" {
+			placement = strings.ToUpper(nulls)
+		}
+
+		switch {
+		case placement == "":
+		case placement != NullsFirst && placement != NullsLast:
+			return "", fmt.Errorf("%w: nulls %q", ErrInvalidOrder, placement)
+		case dialect == "postgres" || dialect == "sqlite3":
+			orders = append(orders, fmt.Sprintf("%s %s NULLS %s", col, direction, placement))
+			continue
+		case dialect == "mysql" && placement == NullsFirst:
+			orders = append(orders, fmt.Sprintf("ISNULL(%s) DESC", col))
+		case dialect == "mysql":
+			orders = append(orders, fmt.Sprintf("ISNULL(%s)", col))
+		case placement == NullsFirst:
+			orders = append(orders, fmt.Sprintf("CASE WHEN %s IS NULL THEN 0 ELSE 1 END", col))
+		default:
+			orders = append(orders, fmt.Sprintf("CASE WHEN %s IS NULL THEN 1 ELSE 0 END", col))
+		}
+
+		if direction == OrderDESC {
+			col += " DESC"
+		}
+		orders = append(orders, col)
+	}
+
+	return strings.Join(orders, ", "), nil
+}
+
+// quoteColumn quote the column name, also the table prefix
+func quoteColumn(quote func(string) string) func(field string) (string, error) {
+	return func(field string) (string, error) {
+		parts := strings.Split(field, ".")
+		for i, v := range parts {
+			parts[i] = quote(v)
+		}
+		return strings.Join(parts, "."), nil
+	}
+}
+
+// OrderByValues scope: order by the column in the order of values, the other values last,
+// FIELD(column, ...) on MySQL, CASE expression on the others, values are bound parameters
+func OrderByValues(column string, values ...interface{}) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(values) == 0 {
+			return db
+		}
+
+		col := column
+		if regexpSortField.MatchString(col) {
+			col, _ = quoteColumn(db.Dialect().Quote)(col)
+		}
+		holders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+
+		// FIELD() returns 0 for the other values, sort the reversed values DESC to keep them last
+		if db.Dialect().GetName() == "mysql" {
+			var args = make([]interface{}, len(values))
+			for i, v := range values {
+				args[len(values)-1-i] = v
+			}
+			return db.Order(gorm.Expr(fmt.Sprintf("FIELD(%s, %s) DESC", col, holders), args...))
+		}
+
+		var expr = "CASE " + col
+		for i := range values {
+			expr += fmt.Sprintf(" WHEN ? THEN %d", i)
+		}
+		expr += fmt.Sprintf(" ELSE %d END", len(values))
+		return db.Order(gorm.Expr(expr, values...))
+	}
 }
@@ -1,6 +1,12 @@
 package gormer
 
-import "github.com/jinzhu/gorm"
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
 
 // PageParam uniform paging parameters
 type PageParam struct {
@@ -50,20 +56,206 @@ func (p *PageParam) Page() func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// CountStrategy how PageResult counts the total rows
+type CountStrategy int
+
+// count strategies
+const (
+	CountExact     CountStrategy = iota // exact COUNT(*), default
+	CountEstimated                      // estimated by the database statistics
+	CountCapped                         // count up to CountCap rows, then report "CountCap+"
+	CountNone                           // no total count
+)
+
+// DefaultCountCap default max rows counted by CountCapped
+const DefaultCountCap = 1000
+
 // PageResult unified paging response structure
 type PageResult struct {
 	PageParam
-	TotalCount int `json:"total_count"`
-	CurrCount  int `json:"curr_count"`
+	TotalCount      int           `json:"total_count"`
+	CurrCount       int           `json:"curr_count"`
+	TotalIsEstimate bool          `json:"total_is_estimate"` // total count is not exact, estimated, capped or not counted
+	CountStrategy   CountStrategy `json:"-"`                 // (optional) strategy of counting total, default CountExact
+	CountCap        int           `json:"-"`                 // (optional) max rows counted by CountCapped, default DefaultCountCap
+	TotalPages      int           `json:"total_pages"`       // number of pages
+	HasNext         bool          `json:"has_next"`          // whether has the next page
+	HasPrev         bool          `json:"has_prev"`          // whether has the previous page
+	NextPage        int           `json:"next_page"`         // next page number, 0 if no next page
+	PrevPage        int           `json:"prev_page"`         // previous page number, 0 if no previous page
 }
 
 // Count count result
-func (p *PageResult) Count(db *gorm.DB) error {
-	if p.IgnorePage != 0 {
+func (p *PageResult) Count(db *gorm.DB) (err error) {
+	if p.IgnorePage == 0 {
+		err = p.count(db)
+	}
+	if err == nil {
+		p.fillMeta()
+	}
+	return
+}
+
+// count count total by CountStrategy
+func (p *PageResult) count(db *gorm.DB) error {
+	switch p.CountStrategy {
+	case CountEstimated:
+		return p.countEstimated(db)
+	case CountCapped:
+		return p.countCapped(db)
+	case CountNone:
+		p.TotalIsEstimate = true
 		return nil
 	}
 
-	return db.Count(&p.TotalCount).Error
+	p.TotalIsEstimate = false
+	return db.Offset(-1).Limit(-1).Count(&p.TotalCount).Error
+}
+
+// countCapped count up to CountCap rows, report CountCap if reached
+func (p *PageResult) countCapped(db *gorm.DB) error {
+	limit := p.CountCap
+	if limit <= 0 {
+		limit = DefaultCountCap
+	}
+
+	var total int
+	query := db.Select("1").Order(nil, true).Offset(-1).Limit(limit + 1).QueryExpr()
+	err := db.New().Raw("SELECT COUNT(*) FROM (?) AS count_table", query).Row().Scan(&total)
+	if err != nil {
+		return err
+	}
+
+	p.TotalIsEstimate = total > limit
+	if p.TotalIsEstimate {
+		total = limit
+	}
+	p.TotalCount = total
+	return nil
+}
+
+// countEstimated estimate total rows by the database statistics,
+// the table statistics are only used while the query has no condition, join or group,
+// MySQL: EXPLAIN rows, or information_schema.TABLES.TABLE_ROWS without conditions,
+// PostgreSQL: pg_class.reltuples without conditions, or EXPLAIN (FORMAT JSON) "Plan Rows",
+// fallback to capped count while no estimation of the filtered query, exact count for other dialects
+func (p *PageResult) countEstimated(db *gorm.DB) error {
+	var total int64 = -1
+	var err error
+	filtered := hasConditions(db)
+
+	switch db.Dialect().GetName() {
+	case "mysql":
+		total, err = explainRows(db)
+		if !filtered && (err != nil || total <= 0) {
+			err = db.New().Raw(
+				"SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+				TableName(db),
+			).Row().Scan(&total)
+		}
+	case "postgres":
+		if !filtered {
+			var tuples sql.NullFloat64
+			err = db.New().Raw(
+				"SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)",
+				TableName(db),
+			).Row().Scan(&tuples)
+			total = int64(tuples.Float64)
+			if !tuples.Valid || tuples.Float64 < 0 {
+				// never vacuumed or analyzed
+				total = -1
+			}
+		}
+		if filtered || err != nil || total < 0 {
+			total, err = pgExplainRows(db)
+		}
+	default:
+		p.TotalIsEstimate = false
+		return db.Offset(-1).Limit(-1).Count(&p.TotalCount).Error
+	}
+
+	// no estimation
+	if err != nil || total < 0 {
+		if filtered {
+			return p.countCapped(db)
+		}
+		p.TotalIsEstimate = false
+		return db.Offset(-1).Limit(-1).Count(&p.TotalCount).Error
+	}
+
+	p.TotalIsEstimate = true
+	p.TotalCount = int(total)
+	return nil
+}
+
+// hasConditions whether the query has WHERE, JOIN, GROUP BY or HAVING
+func hasConditions(db *gorm.DB) bool {
+	scope := db.Order(nil, true).Offset(-1).Limit(-1).NewScope(db.Value)
+	return strings.TrimSpace(scope.CombinedConditionSql()) != ""
+}
+
+// pgExplainRows fetch the estimated rows of query from PostgreSQL EXPLAIN (FORMAT JSON)
+func pgExplainRows(db *gorm.DB) (int64, error) {
+	query := db.Order(nil, true).Offset(-1).Limit(-1).QueryExpr()
+
+	var plan string
+	if err := db.New().Raw("EXPLAIN (FORMAT JSON) ?", query).Row().Scan(&plan); err != nil {
+		return -1, err
+	}
+
+	var result []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &result); err != nil {
+		return -1, err
+	}
+	if len(result) == 0 {
+		return -1, nil
+	}
+	return int64(result[0].Plan.Rows), nil
+}
+
+// explainRows fetch the estimated rows of query from MySQL EXPLAIN, -1 if no estimation
+func explainRows(db *gorm.DB) (total int64, err error) {
+	total = -1
+	query := db.Order(nil, true).Offset(-1).Limit(-1).QueryExpr()
+	rows, err := db.New().Raw("EXPLAIN ?", query).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	var idx = -1
+	for i, c := range columns {
+		if strings.EqualFold(c, "rows") {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return
+	}
+
+	// the rows of first table in execution plan
+	if rows.Next() {
+		var values = make([]interface{}, len(columns))
+		var estimated sql.NullInt64
+		for i := range values {
+			values[i] = new(sql.RawBytes)
+		}
+		values[idx] = &estimated
+		if err = rows.Scan(values...); err != nil {
+			return
+		}
+		total = estimated.Int64
+	}
+
+	return total, rows.Err()
 }
 
 // Scan scan result and count
@@ -80,9 +272,40 @@ func (p *PageResult) Scan(db *gorm.DB, dest interface{}) (err error) {
 	p.CurrCount = int(db.RowsAffected)
 	if p.IgnorePage != 0 {
 		p.TotalCount = p.CurrCount
+	}
+
+	return p.Count(db)
+}
+
+// fillMeta compute the derived paging fields from total count
+func (p *PageResult) fillMeta() {
+	p.TotalPages, p.HasNext, p.HasPrev, p.NextPage, p.PrevPage = 0, false, false, 0, 0
+
+	// all in one page
+	if p.IgnorePage != 0 {
+		if p.TotalCount > 0 {
+			p.TotalPages = 1
+		}
+		return
+	}
+
+	p.Init()
+	if p.TotalCount > 0 {
+		p.TotalPages = (p.TotalCount + p.PageSize - 1) / p.PageSize
+	}
+
+	// the total is unknown, has next while the current page is full
+	if p.CountStrategy == CountNone {
+		p.HasNext = p.CurrCount >= p.PageSize
 	} else {
-		return p.Count(db)
+		p.HasNext = p.CurrPage < p.TotalPages || (p.TotalIsEstimate && p.CurrCount >= p.PageSize)
 	}
+	p.HasPrev = p.CurrPage > 1
 
-	return
+	if p.HasNext {
+		p.NextPage = p.CurrPage + 1
+	}
+	if p.HasPrev {
+		p.PrevPage = p.CurrPage - 1
+	}
 }
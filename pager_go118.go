@@ -0,0 +1,23 @@
+package gormer
+
+import "github.com/jinzhu/gorm"
+
+// Page generic paging response structure
+type Page[T any] struct {
+	PageResult
+	Items []T `json:"items"`
+}
+
+// Paginate query a page of items and count the total
+func Paginate[T any](db *gorm.DB, param PageParam) (Page[T], error) {
+	var page = Page[T]{Items: []T{}}
+	page.PageParam = param
+
+	// query from the table of T, while no model or table specified
+	if db.Value == nil {
+		db = db.Model(new(T))
+	}
+
+	err := page.Scan(db.Scopes(page.Page()), &page.Items)
+	return page, err
+}
@@ -1,10 +1,57 @@
 package gormer
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
 )
 
+// ScanPreviewSize max bytes of payload preview in ScanError
+const ScanPreviewSize = 64
+
+// ScanNull how to handle the NULL value of DB field
+type ScanNull int
+
+// NULL handling
+const (
+	ScanNullKeep ScanNull = iota // leave the target untouched
+	ScanNullZero                 // reset the target to zero value
+)
+
+// ErrScanTarget the target is not a non-nil pointer
+var ErrScanTarget = errors.New("scan target must be a non-nil pointer")
+
+// ScanError decoding DB field error
+type ScanError struct {
+	Type    string // target type
+	Preview string // truncated payload
+	Err     error
+}
+
+// Error implements error
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("scan into %s: %v, payload: %q", e.Type, e.Err, e.Preview)
+}
+
+// Unwrap return the underlying error
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// newScanError wrap err with the target type and payload preview
+func newScanError(f interface{}, payload []byte, err error) *ScanError {
+	preview := string(payload)
+	if len(payload) > ScanPreviewSize {
+		preview = string(payload[:ScanPreviewSize]) + "..."
+	}
+	return &ScanError{Type: fmt.Sprintf("%T", f), Preview: preview, Err: err}
+}
+
 // JSONScanner decoding DB field(JSON string) to custom type
 func JSONScanner(f interface{}, value interface{}) error {
 	switch value := value.(type) {
@@ -16,11 +63,30 @@ func JSONScanner(f interface{}, value interface{}) error {
 	return nil
 }
 
-// JSONValuer encoding custom type to JSON string
+// JSONStrictScanner decoding DB field(JSON string) to custom type,
+// f must be a non-nil pointer, return ScanError while unsupported source type or invalid JSON
+func JSONStrictScanner(f interface{}, value interface{}, null ScanNull) error {
+	return CodecScanner(JSONCodec{}, f, value, null)
+}
+
+// JSONValuer encoding custom type to JSON string, for NOT NULL column,
+// the nil value is encoded as empty JSON by the type, [] for slice, {} for map and struct
 func JSONValuer(f interface{}) (driver.Value, error) {
 	if f == nil {
 		return "{}", nil
 	}
+
+	rv := reflect.ValueOf(f)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return emptyJSON(rv.Type()), nil
+		}
+		rv = rv.Elem()
+	}
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.IsNil() {
+		return emptyJSON(rv.Type()), nil
+	}
+
 	s, err := json.Marshal(f)
 	if err != nil {
 		return "{}", err
@@ -28,3 +94,107 @@ func JSONValuer(f interface{}) (driver.Value, error) {
 
 	return string(s), nil
 }
+
+// JSONNullValuer encoding custom type to JSON string, for nullable column,
+// the nil pointer and nil interface are encoded as NULL, the others same as JSONValuer
+func JSONNullValuer(f interface{}) (driver.Value, error) {
+	if isNilValue(f) {
+		return nil, nil
+	}
+	return JSONValuer(f)
+}
+
+// isNilValue whether nil, nil pointer or nil interface
+func isNilValue(f interface{}) bool {
+	rv := reflect.ValueOf(f)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	return !rv.IsValid()
+}
+
+// emptyJSON empty JSON of the type, [] for slice and array, {} for map and struct, otherwise null
+func emptyJSON(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "[]"
+	case reflect.Map, reflect.Struct, reflect.Interface:
+		return "{}"
+	}
+	return "null"
+}
+
+// JSONField JSON column of any type for the Go version without generics,
+// implements sql.Scanner, driver.Valuer and json.Marshaler,
+// Data is decoded as interface{}(map[string]interface{}, []interface{}...), nil Data as NULL,
+// or into the target while Data is a pointer, e.g. scan by sql.Row manually
+type JSONField struct {
+	Data interface{}
+}
+
+var _ sql.Scanner = &JSONField{}
+var _ driver.Valuer = JSONField{}
+var _ json.Marshaler = JSONField{}
+var _ json.Unmarshaler = &JSONField{}
+
+// Scan implements sql.Scanner
+func (j *JSONField) Scan(value interface{}) error {
+	if j.Data == nil {
+		return JSONStrictScanner(&j.Data, value, ScanNullZero)
+	}
+
+	// decode into the pointer directly
+	rv := reflect.ValueOf(j.Data)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return JSONStrictScanner(j.Data, value, ScanNullZero)
+	}
+
+	// decode into a new value of the same type
+	ptr := reflect.New(rv.Type())
+	if err := JSONStrictScanner(ptr.Interface(), value, ScanNullZero); err != nil {
+		return err
+	}
+	j.Data = ptr.Elem().Interface()
+	return nil
+}
+
+// Value implements driver.Valuer
+func (j JSONField) Value() (driver.Value, error) {
+	return JSONNullValuer(j.Data)
+}
+
+// MarshalJSON implements json.Marshaler
+func (j JSONField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (j *JSONField) UnmarshalJSON(data []byte) error {
+	if j.Data == nil {
+		return json.Unmarshal(data, &j.Data)
+	}
+	return j.Scan(data)
+}
+
+// GormDataType the data type of column, by dialect
+func (JSONField) GormDataType(dialect gorm.Dialect) string {
+	return jsonDataType(dialect)
+}
+
+// jsonDataType the JSON column type of dialect
+func jsonDataType(dialect gorm.Dialect) string {
+	switch dialect.GetName() {
+	case "mysql":
+		return "json"
+	case "postgres":
+		return "jsonb"
+	}
+	return textDataType(dialect)
+}
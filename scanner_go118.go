@@ -0,0 +1,87 @@
+package gormer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/jinzhu/gorm"
+)
+
+// JSON JSON column of type T, implements sql.Scanner, driver.Valuer and json.Marshaler,
+// nil pointer T is stored as NULL, nil slice as [], nil map as {}
+type JSON[T any] struct {
+	Data T
+}
+
+var _ sql.Scanner = &JSON[struct{}]{}
+var _ driver.Valuer = JSON[struct{}]{}
+var _ json.Marshaler = JSON[struct{}]{}
+var _ json.Unmarshaler = &JSON[struct{}]{}
+
+// Scan implements sql.Scanner
+func (j *JSON[T]) Scan(value interface{}) error {
+	return JSONStrictScanner(&j.Data, value, ScanNullZero)
+}
+
+// Value implements driver.Valuer
+func (j JSON[T]) Value() (driver.Value, error) {
+	return JSONNullValuer(j.Data)
+}
+
+// MarshalJSON implements json.Marshaler
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &j.Data)
+}
+
+// GormDataType the data type of column, by dialect
+func (JSON[T]) GormDataType(dialect gorm.Dialect) string {
+	return jsonDataType(dialect)
+}
+
+// Encoded column of type T, encoded by codec C, e.g. Encoded[Extra, MsgpackCodec],
+// implements sql.Scanner, driver.Valuer and json.Marshaler, nil pointer T is stored as NULL
+type Encoded[T any, C Codec] struct {
+	Data T
+}
+
+var _ sql.Scanner = &Encoded[struct{}, GobCodec]{}
+var _ driver.Valuer = Encoded[struct{}, GobCodec]{}
+var _ json.Marshaler = Encoded[struct{}, GobCodec]{}
+var _ json.Unmarshaler = &Encoded[struct{}, GobCodec]{}
+
+// Scan implements sql.Scanner
+func (e *Encoded[T, C]) Scan(value interface{}) error {
+	var c C
+	return CodecScanner(c, &e.Data, value, ScanNullZero)
+}
+
+// Value implements driver.Valuer
+func (e Encoded[T, C]) Value() (driver.Value, error) {
+	var c C
+	if _, ok := Codec(c).(JSONCodec); ok {
+		return JSONNullValuer(e.Data)
+	}
+	return CodecValuer(c, e.Data)
+}
+
+// MarshalJSON implements json.Marshaler
+func (e Encoded[T, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *Encoded[T, C]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &e.Data)
+}
+
+// GormDataType the data type of column, by dialect and codec
+func (Encoded[T, C]) GormDataType(dialect gorm.Dialect) string {
+	var c C
+	return codecDataType(c, dialect)
+}
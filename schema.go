@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/blastrain/vitess-sqlparser/sqlparser"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/ast"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/parser"
 )
 
 var regexpUnsigned, _ = regexp.Compile(`(?i)\sUNSIGNED`)
@@ -29,11 +31,20 @@ type Schema struct {
 
 // Key table index key
 type Key struct {
-	Name        string   `json:"name"`
-	IndexType   string   `json:"index_type"`
-	IndexMethod string   `json:"index_method"`
-	Comment     string   `json:"comment"`
-	Columns     []string `json:"columns"`
+	Name        string        `json:"name"`
+	IndexType   string        `json:"index_type"`
+	IndexMethod string        `json:"index_method"`
+	Comment     string        `json:"comment"`
+	Columns     []string      `json:"columns"`
+	Reference   *KeyReference `json:"reference,omitempty"` // the referenced table of FOREIGN KEY
+}
+
+// KeyReference the REFERENCES clause of foreign key
+type KeyReference struct {
+	Table    string   `json:"table"`
+	Columns  []string `json:"columns"`
+	OnDelete string   `json:"on_delete"` // RESTRICT, CASCADE, SET NULL, NO ACTION
+	OnUpdate string   `json:"on_update"`
 }
 
 // Column table field info
@@ -46,6 +57,7 @@ type Column struct {
 	Charset       string      `json:"charset"`
 	Collate       string      `json:"collate"`
 	Default       interface{} `json:"default"`
+	OnUpdate      string      `json:"on_update,omitempty"` // ON UPDATE CURRENT_TIMESTAMP of TIMESTAMP and DATETIME
 	Comment       string      `json:"comment"`
 }
 
@@ -77,6 +89,9 @@ func (s *Schema) Parse() error {
 
 		s.Keys = append(s.Keys, key)
 	}
+	if err = s.parseReferences(); err != nil {
+		return err
+	}
 
 	// options
 	for _, v := range ddl.Options {
@@ -96,48 +111,97 @@ func (s *Schema) Parse() error {
 
 	// columns
 	for _, v := range ddl.Columns {
-		var col = &Column{
-			Name: v.Name,
-			Type: v.Type,
-		}
-		unsigned := regexpUnsigned.FindStringSubmatch(col.Type)
-		if len(unsigned) >= 1 {
-			col.Unsigned = true
-			col.Type = strings.Replace(col.Type, unsigned[0], "", 1)
-		}
-		charset := regexpCharset.FindStringSubmatch(col.Type)
-		if len(charset) >= 2 {
-			col.Charset = charset[1]
-			col.Type = strings.Replace(col.Type, charset[0], "", 1)
-		}
-		collate := regexpCollate.FindStringSubmatch(col.Type)
-		if len(collate) >= 2 {
-			col.Collate = collate[1]
-			col.Type = strings.Replace(col.Type, collate[0], "", 1)
-		}
+		s.Columns = append(s.Columns, newColumn(v.Name, v.Type, v.Options))
+	}
 
-		// column option
-		for _, opt := range v.Options {
-			switch opt.Type {
-			case sqlparser.ColumnOptionDefaultValue:
-				col.Default = strings.Trim(opt.Value, `"`)
-			case sqlparser.ColumnOptionNotNull:
-				col.NotNull = true
-			case sqlparser.ColumnOptionNull:
-				col.NotNull = false
-			case sqlparser.ColumnOptionAutoIncrement:
-				col.AutoIncrement = true
-			case sqlparser.ColumnOptionComment:
-				col.Comment = strings.Trim(opt.Value, `"`)
-			}
-		}
+	return nil
+}
 
-		s.Columns = append(s.Columns, col)
+// parseReferences the REFERENCES of foreign keys, which are dropped by sqlparser
+func (s *Schema) parseReferences() error {
+	var foreign bool
+	for _, k := range s.Keys {
+		foreign = foreign || isForeignKey(k)
+	}
+	if !foreign {
+		return nil
 	}
 
+	stmts, err := parser.New().Parse(s.RawDDL, "", "")
+	if err != nil {
+		return err
+	}
+	ddl, ok := stmts[0].(*ast.CreateTableStmt)
+	if !ok || len(ddl.Constraints) != len(s.Keys) {
+		return errors.New("DDL is not a CREATE statement")
+	}
+	for i, c := range ddl.Constraints {
+		s.Keys[i].Reference = newKeyReference(c.Refer)
+	}
 	return nil
 }
 
+// newKeyReference key reference from the REFERENCES clause, nil if no clause
+func newKeyReference(refer *ast.ReferenceDef) *KeyReference {
+	if refer == nil {
+		return nil
+	}
+	var r = &KeyReference{Table: refer.Table.Name.String()}
+	for _, c := range refer.IndexColNames {
+		r.Columns = append(r.Columns, c.Column.Name.String())
+	}
+	if refer.OnDelete != nil {
+		r.OnDelete = refer.OnDelete.ReferOpt.String()
+	}
+	if refer.OnUpdate != nil {
+		r.OnUpdate = refer.OnUpdate.ReferOpt.String()
+	}
+	return r
+}
+
+// newColumn column info from the column definition
+func newColumn(name, tp string, options []*sqlparser.ColumnOption) *Column {
+	var col = &Column{
+		Name: name,
+		Type: tp,
+	}
+	unsigned := regexpUnsigned.FindStringSubmatch(col.Type)
+	if len(unsigned) >= 1 {
+		col.Unsigned = true
+		col.Type = strings.Replace(col.Type, unsigned[0], "", 1)
+	}
+	charset := regexpCharset.FindStringSubmatch(col.Type)
+	if len(charset) >= 2 {
+		col.Charset = charset[1]
+		col.Type = strings.Replace(col.Type, charset[0], "", 1)
+	}
+	collate := regexpCollate.FindStringSubmatch(col.Type)
+	if len(collate) >= 2 {
+		col.Collate = collate[1]
+		col.Type = strings.Replace(col.Type, collate[0], "", 1)
+	}
+
+	// column option
+	for _, opt := range options {
+		switch opt.Type {
+		case sqlparser.ColumnOptionDefaultValue:
+			col.Default = strings.Trim(opt.Value, `"`)
+		case sqlparser.ColumnOptionNotNull:
+			col.NotNull = true
+		case sqlparser.ColumnOptionNull:
+			col.NotNull = false
+		case sqlparser.ColumnOptionAutoIncrement:
+			col.AutoIncrement = true
+		case sqlparser.ColumnOptionOnUpdate:
+			col.OnUpdate = opt.Value
+		case sqlparser.ColumnOptionComment:
+			col.Comment = strings.Trim(opt.Value, `"`)
+		}
+	}
+
+	return col
+}
+
 // Markdown return markdown format content
 func (s Schema) Markdown() string {
 	var ms = `
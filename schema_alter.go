@@ -0,0 +1,306 @@
+package gormer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/ast"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/parser"
+)
+
+// ErrUnsupportedAlter the ALTER statement or specification is not supported
+var ErrUnsupportedAlter = errors.New("unsupported ALTER statement")
+
+// Apply apply the ALTER TABLE statements on the schema, separated by ";", e.g. the migration history,
+// support ADD/DROP/MODIFY/CHANGE COLUMN, ADD/DROP INDEX/KEY/PRIMARY KEY/FOREIGN KEY, ALTER COLUMN SET/DROP DEFAULT,
+// RENAME TO, table options, LOCK(ignored), and CREATE/DROP INDEX, RENAME TABLE,
+// RawDDL is left untouched, the statements must be on the same table,
+// the statements before the failed one are kept applied
+func (s *Schema) Apply(ddl string) error {
+	stmts, err := parser.New().Parse(ddl, "", "")
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range stmts {
+		if err = s.applyStmt(stmt); err != nil {
+			return fmt.Errorf("%s: %w", strings.TrimSpace(stmt.Text()), err)
+		}
+	}
+	return nil
+}
+
+// applyStmt apply a statement
+func (s *Schema) applyStmt(stmt ast.StmtNode) error {
+	switch stmt := stmt.(type) {
+	case *ast.AlterTableStmt:
+		if err := s.checkTable(stmt.Table); err != nil {
+			return err
+		}
+		for _, spec := range stmt.Specs {
+			if err := s.applySpec(spec); err != nil {
+				return err
+			}
+		}
+	case *ast.CreateIndexStmt:
+		if err := s.checkTable(stmt.Table); err != nil {
+			return err
+		}
+		var key = &Key{Name: stmt.IndexName, IndexType: sqlparser.ConstraintIndex.String()}
+		if stmt.Unique {
+			key.IndexType = sqlparser.ConstraintUniqIndex.String()
+		}
+		for _, c := range stmt.IndexColNames {
+			key.Columns = append(key.Columns, c.Column.Name.String())
+		}
+		return s.addKey(key)
+	case *ast.DropIndexStmt:
+		if err := s.checkTable(stmt.Table); err != nil {
+			return err
+		}
+		if err := s.dropKey(stmt.IndexName, false); err != nil && !stmt.IfExists {
+			return err
+		}
+	case *ast.RenameTableStmt:
+		// renamed in order, the pairs on the other tables are skipped
+		var renamed bool
+		for _, t := range stmt.TableToTables {
+			if strings.EqualFold(t.OldTable.Name.String(), s.TableName) {
+				s.TableName = t.NewTable.Name.String()
+				renamed = true
+			}
+		}
+		if !renamed {
+			return s.checkTable(stmt.OldTable)
+		}
+	default:
+		return ErrUnsupportedAlter
+	}
+	return nil
+}
+
+// checkTable the statement is on the table of schema
+func (s *Schema) checkTable(table *ast.TableName) error {
+	if !strings.EqualFold(table.Name.String(), s.TableName) {
+		return fmt.Errorf("table %q is not %q", table.Name.String(), s.TableName)
+	}
+	return nil
+}
+
+// applySpec apply a specification of ALTER TABLE
+func (s *Schema) applySpec(spec *ast.AlterTableSpec) error {
+	switch spec.Tp {
+	case ast.AlterTableOption:
+		for _, opt := range spec.Options {
+			switch sqlparser.TableOptionType(opt.Tp) {
+			case sqlparser.TableOptionEngine:
+				s.Engine = opt.StrValue
+			case sqlparser.TableOptionCharset:
+				s.DefaultCharset = opt.StrValue
+			case sqlparser.TableOptionAutoIncrement:
+				s.AutoIncrement = opt.UintValue
+			case sqlparser.TableOptionCollate:
+				s.DefaultCollation = opt.StrValue
+			case sqlparser.TableOptionComment:
+				s.Comment = opt.StrValue
+			}
+		}
+	case ast.AlterTableAddColumns:
+		for i, def := range spec.NewColumns {
+			col := alterColumn(def)
+			if s.columnIndex(col.Name) >= 0 {
+				return fmt.Errorf("column %q already exists", col.Name)
+			}
+			pos := spec.Position
+			if i > 0 {
+				// ADD COLUMN (a, b) has no position
+				pos = nil
+			}
+			if err := s.insertColumn(col, pos); err != nil {
+				return err
+			}
+		}
+	case ast.AlterTableAddConstraint:
+		var key = &Key{
+			Name:      spec.Constraint.Name,
+			IndexType: sqlparser.ConstraintType(spec.Constraint.Tp).String(),
+			Reference: newKeyReference(spec.Constraint.Refer),
+		}
+		for _, k := range spec.Constraint.Keys {
+			key.Columns = append(key.Columns, k.Column.Name.String())
+		}
+		return s.addKey(key)
+	case ast.AlterTableDropColumn:
+		return s.dropColumn(spec.OldColumnName.Name.String())
+	case ast.AlterTableDropPrimaryKey:
+		for i, k := range s.Keys {
+			if k.IndexType == sqlparser.ConstraintPrimaryKey.String() {
+				s.Keys = append(s.Keys[:i], s.Keys[i+1:]...)
+				return nil
+			}
+		}
+		return errors.New("primary key not exists")
+	case ast.AlterTableDropIndex:
+		return s.dropKey(spec.Name, false)
+	case ast.AlterTableDropForeignKey:
+		return s.dropKey(spec.Name, true)
+	case ast.AlterTableModifyColumn:
+		col := alterColumn(spec.NewColumns[0])
+		return s.replaceColumn(col.Name, col, spec.Position)
+	case ast.AlterTableChangeColumn:
+		col := alterColumn(spec.NewColumns[0])
+		return s.replaceColumn(spec.OldColumnName.Name.String(), col, spec.Position)
+	case ast.AlterTableRenameTable:
+		s.TableName = spec.NewTable.Name.String()
+	case ast.AlterTableLock:
+		// LOCK=NONE/SHARED/EXCLUSIVE, the table is not changed
+	case ast.AlterTableAlterColumn:
+		def := spec.NewColumns[0]
+		idx := s.columnIndex(def.Name.Name.String())
+		if idx < 0 {
+			return fmt.Errorf("column %q not exists", def.Name.Name.String())
+		}
+		// SET DEFAULT with the value option, DROP DEFAULT without
+		s.Columns[idx].Default = nil
+		if len(def.Options) > 0 {
+			s.Columns[idx].Default = strings.Trim(formatExpr(def.Options[0].Expr), `"`)
+		}
+	default:
+		return ErrUnsupportedAlter
+	}
+	return nil
+}
+
+// alterColumn column info from the column definition of ALTER
+func alterColumn(def *ast.ColumnDef) *Column {
+	var options []*sqlparser.ColumnOption
+	for _, opt := range def.Options {
+		options = append(options, &sqlparser.ColumnOption{
+			Type:  sqlparser.ColumnOptionType(opt.Tp),
+			Value: formatExpr(opt.Expr),
+		})
+	}
+	return newColumn(def.Name.Name.String(), def.Tp.String(), options)
+}
+
+// formatExpr restore the expression, same as Parse
+func formatExpr(expr ast.ExprNode) string {
+	if expr == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	expr.Format(&buf)
+	return buf.String()
+}
+
+// columnIndex index of the column by name, -1 if not exists
+func (s *Schema) columnIndex(name string) int {
+	for i, c := range s.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertColumn insert the column at position, append if no position
+func (s *Schema) insertColumn(col *Column, pos *ast.ColumnPosition) error {
+	idx := len(s.Columns)
+	if pos != nil {
+		switch pos.Tp {
+		case ast.ColumnPositionFirst:
+			idx = 0
+		case ast.ColumnPositionAfter:
+			idx = s.columnIndex(pos.RelativeColumn.Name.String())
+			if idx < 0 {
+				return fmt.Errorf("column %q not exists", pos.RelativeColumn.Name.String())
+			}
+			idx++
+		}
+	}
+
+	s.Columns = append(s.Columns, nil)
+	copy(s.Columns[idx+1:], s.Columns[idx:])
+	s.Columns[idx] = col
+	return nil
+}
+
+// replaceColumn replace the column by name, rename it in keys, move if position given
+func (s *Schema) replaceColumn(name string, col *Column, pos *ast.ColumnPosition) error {
+	idx := s.columnIndex(name)
+	if idx < 0 {
+		return fmt.Errorf("column %q not exists", name)
+	}
+	if !strings.EqualFold(name, col.Name) && s.columnIndex(col.Name) >= 0 {
+		return fmt.Errorf("column %q already exists", col.Name)
+	}
+
+	for _, k := range s.Keys {
+		for i, c := range k.Columns {
+			if strings.EqualFold(c, name) {
+				k.Columns[i] = col.Name
+			}
+		}
+	}
+
+	if pos == nil || pos.Tp == ast.ColumnPositionNone {
+		s.Columns[idx] = col
+		return nil
+	}
+	s.Columns = append(s.Columns[:idx], s.Columns[idx+1:]...)
+	return s.insertColumn(col, pos)
+}
+
+// dropColumn drop the column, and remove it from keys, drop the key if no column left
+func (s *Schema) dropColumn(name string) error {
+	idx := s.columnIndex(name)
+	if idx < 0 {
+		return fmt.Errorf("column %q not exists", name)
+	}
+	s.Columns = append(s.Columns[:idx], s.Columns[idx+1:]...)
+
+	var keys []*Key
+	for _, k := range s.Keys {
+		var columns []string
+		for _, c := range k.Columns {
+			if !strings.EqualFold(c, name) {
+				columns = append(columns, c)
+			}
+		}
+		if len(columns) > 0 {
+			k.Columns = columns
+			keys = append(keys, k)
+		}
+	}
+	s.Keys = keys
+	return nil
+}
+
+// addKey add the key, the name and primary key must be unique, the foreign keys are named separately
+func (s *Schema) addKey(key *Key) error {
+	foreign := isForeignKey(key)
+	for _, k := range s.Keys {
+		if key.Name != "" && strings.EqualFold(k.Name, key.Name) && isForeignKey(k) == foreign {
+			return fmt.Errorf("key %q already exists", key.Name)
+		}
+		if key.IndexType == sqlparser.ConstraintPrimaryKey.String() && k.IndexType == key.IndexType {
+			return errors.New("primary key already exists")
+		}
+	}
+	s.Keys = append(s.Keys, key)
+	return nil
+}
+
+// dropKey drop the index or foreign key by name, the unnamed key by the name generated by MySQL
+func (s *Schema) dropKey(name string, foreign bool) error {
+	for i, k := range s.Keys {
+		if strings.EqualFold(s.keyName(k), name) && isForeignKey(k) == foreign {
+			s.Keys = append(s.Keys[:i], s.Keys[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("key %q not exists", name)
+}
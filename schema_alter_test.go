@@ -0,0 +1,64 @@
+package gormer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchemaApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		ddl     string
+		table   string
+		columns []string
+		wantErr bool
+	}{
+		{
+			name:    "lock",
+			ddl:     "ALTER TABLE user ADD COLUMN age int, LOCK=NONE",
+			table:   "user",
+			columns: []string{"id", "age"},
+		},
+		{
+			name:    "rename table",
+			ddl:     "RENAME TABLE other TO x, user TO member",
+			table:   "member",
+			columns: []string{"id"},
+		},
+		{
+			name:    "rename table in order",
+			ddl:     "RENAME TABLE user TO tmp, other TO user, tmp TO member",
+			table:   "member",
+			columns: []string{"id"},
+		},
+		{
+			name:    "rename other table",
+			ddl:     "RENAME TABLE other TO x",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := parseSchema(t, "CREATE TABLE `user` (`id` int(11) NOT NULL)")
+			err := s.Apply(tt.ddl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if s.TableName != tt.table {
+				t.Errorf("TableName = %s, want %s", s.TableName, tt.table)
+			}
+			var columns []string
+			for _, c := range s.Columns {
+				columns = append(columns, c.Name)
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("columns = %v, want %v", columns, tt.columns)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package gormer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var regexpDefaultExpr, _ = regexp.Compile(`(?i)^(NULL|CURRENT_TIMESTAMP|NOW|LOCALTIME|LOCALTIMESTAMP)(\(\d*\))?$`)
+
+// SchemaDiff the difference from Old to New schema
+type SchemaDiff struct {
+	Old *Schema `json:"-"`
+	New *Schema `json:"-"`
+
+	AddedColumns   []*Column      `json:"added_columns"`
+	RemovedColumns []*Column      `json:"removed_columns"`
+	ChangedColumns []ColumnChange `json:"changed_columns"`
+	AddedKeys      []*Key         `json:"added_keys"`
+	RemovedKeys    []*Key         `json:"removed_keys"`
+	ChangedKeys    []KeyChange    `json:"changed_keys"`
+	ChangedOptions []OptionChange `json:"changed_options"` // table name, engine, charset, collation and comment
+}
+
+// ColumnChange the column is changed, Fields are the names of changed attributes
+type ColumnChange struct {
+	Old    *Column  `json:"old"`
+	New    *Column  `json:"new"`
+	Fields []string `json:"fields"`
+}
+
+// KeyChange the key is changed, on type, columns or reference
+type KeyChange struct {
+	Old *Key `json:"old"`
+	New *Key `json:"new"`
+}
+
+// OptionChange the table option is changed
+type OptionChange struct {
+	Name string `json:"name"` // table_name, engine, default_charset, default_collation, comment
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// DiffOptions options of generating statements
+type DiffOptions struct {
+	SkipDestructive bool // skip the DROP COLUMN, the data loss changes
+	Down            bool // the reverse migration, from New to Old
+}
+
+// Diff compare the schemas, the columns and keys are matched by name(case-insensitive),
+// the primary key is matched by type, the renamed column is a removed and an added one
+func Diff(from, to *Schema) *SchemaDiff {
+	var d = &SchemaDiff{Old: from, New: to}
+
+	for _, oc := range from.Columns {
+		idx := to.columnIndex(oc.Name)
+		if idx < 0 {
+			d.RemovedColumns = append(d.RemovedColumns, oc)
+			continue
+		}
+		if fields := diffColumn(oc, to.Columns[idx]); len(fields) > 0 {
+			d.ChangedColumns = append(d.ChangedColumns, ColumnChange{Old: oc, New: to.Columns[idx], Fields: fields})
+		}
+	}
+	for _, nc := range to.Columns {
+		if from.columnIndex(nc.Name) < 0 {
+			d.AddedColumns = append(d.AddedColumns, nc)
+		}
+	}
+
+	for _, fk := range from.Keys {
+		tk := findKey(to.Keys, fk)
+		if tk == nil {
+			d.RemovedKeys = append(d.RemovedKeys, fk)
+			continue
+		}
+		if keyKind(fk.IndexType) != keyKind(tk.IndexType) || !strings.EqualFold(strings.Join(fk.Columns, ","), strings.Join(tk.Columns, ",")) ||
+			!strings.EqualFold(referenceSQL(fk.Reference), referenceSQL(tk.Reference)) {
+			d.ChangedKeys = append(d.ChangedKeys, KeyChange{Old: fk, New: tk})
+		}
+	}
+	for _, tk := range to.Keys {
+		if findKey(from.Keys, tk) == nil {
+			d.AddedKeys = append(d.AddedKeys, tk)
+		}
+	}
+
+	for _, opt := range []OptionChange{
+		{Name: "engine", Old: from.Engine, New: to.Engine},
+		{Name: "default_charset", Old: from.DefaultCharset, New: to.DefaultCharset},
+		{Name: "default_collation", Old: from.DefaultCollation, New: to.DefaultCollation},
+		{Name: "comment", Old: from.Comment, New: to.Comment},
+		{Name: "table_name", Old: from.TableName, New: to.TableName},
+	} {
+		// the empty engine, charset or collation is unspecified, e.g. loaded from PostgreSQL or SQLite
+		if (opt.Old == "" || opt.New == "") && opt.Name != "comment" {
+			continue
+		}
+		if opt.Old != opt.New && (opt.Name == "comment" || !strings.EqualFold(opt.Old, opt.New)) {
+			d.ChangedOptions = append(d.ChangedOptions, opt)
+		}
+	}
+
+	return d
+}
+
+// Empty whether no difference
+func (d *SchemaDiff) Empty() bool {
+	return len(d.AddedColumns) == 0 && len(d.RemovedColumns) == 0 && len(d.ChangedColumns) == 0 &&
+		len(d.AddedKeys) == 0 && len(d.RemovedKeys) == 0 && len(d.ChangedKeys) == 0 &&
+		len(d.ChangedOptions) == 0
+}
+
+// Statements the ALTER TABLE statements migrate from Old to New, or New to Old if opts.Down, in order:
+// drop keys, add columns, modify columns, drop columns, add keys, table options, rename table,
+// the foreign keys are dropped before and added after the other keys, which may be required by them
+func (d *SchemaDiff) Statements(opts DiffOptions) []string {
+	if opts.Down {
+		opts.Down = false
+		return Diff(d.New, d.Old).Statements(opts)
+	}
+
+	var stmts []string
+	alter := func(format string, args ...interface{}) {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ", quoteIdent(d.Old.TableName))+fmt.Sprintf(format, args...))
+	}
+
+	for _, foreign := range []bool{true, false} {
+		for _, k := range d.RemovedKeys {
+			if isForeignKey(k) == foreign {
+				alter("%s", dropKeySQL(d.Old, k))
+			}
+		}
+		for _, c := range d.ChangedKeys {
+			if isForeignKey(c.Old) == foreign {
+				alter("%s", dropKeySQL(d.Old, c.Old))
+			}
+		}
+	}
+
+	for _, c := range d.AddedColumns {
+		alter("ADD COLUMN %s%s", c.Definition(), columnPosition(d.New, c.Name))
+	}
+	for _, c := range d.ChangedColumns {
+		alter("MODIFY COLUMN %s", c.New.Definition())
+	}
+	if !opts.SkipDestructive {
+		for _, c := range d.RemovedColumns {
+			alter("DROP COLUMN %s", quoteIdent(c.Name))
+		}
+	}
+
+	for _, foreign := range []bool{false, true} {
+		for _, c := range d.ChangedKeys {
+			if isForeignKey(c.New) == foreign {
+				alter("ADD %s", c.New.Definition())
+			}
+		}
+		for _, k := range d.AddedKeys {
+			if isForeignKey(k) == foreign {
+				alter("ADD %s", k.Definition())
+			}
+		}
+	}
+
+	var rename string
+	for _, opt := range d.ChangedOptions {
+		switch opt.Name {
+		case "engine":
+			alter("ENGINE=%s", opt.New)
+		case "default_charset":
+			alter("DEFAULT CHARSET=%s", opt.New)
+		case "default_collation":
+			alter("COLLATE=%s", opt.New)
+		case "comment":
+			alter("COMMENT=%s", quoteString(opt.New))
+		case "table_name":
+			rename = opt.New
+		}
+	}
+	if rename != "" {
+		alter("RENAME TO %s", quoteIdent(rename))
+	}
+
+	return stmts
+}
+
+// Definition the column definition of DDL, e.g. "`name` varchar(50) NOT NULL DEFAULT 'guest' COMMENT 'name'"
+func (c *Column) Definition() string {
+	var b strings.Builder
+	b.WriteString(quoteIdent(c.Name))
+	b.WriteString(" " + c.Type)
+	if c.Unsigned {
+		b.WriteString(" UNSIGNED")
+	}
+	if c.Charset != "" {
+		b.WriteString(" CHARACTER SET " + c.Charset)
+	}
+	if c.Collate != "" {
+		b.WriteString(" COLLATE " + c.Collate)
+	}
+	if c.NotNull {
+		b.WriteString(" NOT NULL")
+	} else {
+		b.WriteString(" NULL")
+	}
+	if c.AutoIncrement {
+		b.WriteString(" AUTO_INCREMENT")
+	}
+	if def, ok := columnDefault(c); ok {
+		if regexpDefaultExpr.MatchString(def) {
+			b.WriteString(" DEFAULT " + strings.ToUpper(def))
+		} else {
+			b.WriteString(" DEFAULT " + quoteString(def))
+		}
+	}
+	if c.OnUpdate != "" {
+		b.WriteString(" ON UPDATE " + strings.ToUpper(c.OnUpdate))
+	}
+	if c.Comment != "" {
+		b.WriteString(" COMMENT " + quoteString(c.Comment))
+	}
+	return b.String()
+}
+
+// Definition the key definition of DDL, e.g. "UNIQUE KEY `uk_email` (`email`)",
+// "CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE"
+func (k *Key) Definition() string {
+	var kind = keyKind(k.IndexType)
+	switch {
+	case kind == "PRIMARY KEY" || k.Name == "":
+		// unnamed key, the name is generated by MySQL
+		return kind + " " + quoteColumns(k.Columns) + referenceSQL(k.Reference)
+	case kind == "FOREIGN KEY":
+		return "CONSTRAINT " + quoteIdent(k.Name) + " " + kind + " " + quoteColumns(k.Columns) + referenceSQL(k.Reference)
+	}
+	return kind + " " + quoteIdent(k.Name) + " " + quoteColumns(k.Columns)
+}
+
+// referenceSQL the REFERENCES clause of foreign key, with the leading space
+func referenceSQL(r *KeyReference) string {
+	if r == nil {
+		return ""
+	}
+	var sql = " REFERENCES " + quoteIdent(r.Table) + " " + quoteColumns(r.Columns)
+	if r.OnDelete != "" {
+		sql += " ON DELETE " + r.OnDelete
+	}
+	if r.OnUpdate != "" {
+		sql += " ON UPDATE " + r.OnUpdate
+	}
+	return sql
+}
+
+// quoteColumns the quoted column list in parentheses, e.g. "(`a`, `b`)"
+func quoteColumns(columns []string) string {
+	var quoted = make([]string, 0, len(columns))
+	for _, c := range columns {
+		quoted = append(quoted, quoteIdent(c))
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// diffColumn the names of changed attributes
+func diffColumn(o, n *Column) []string {
+	var fields []string
+	if !strings.EqualFold(o.Type, n.Type) {
+		fields = append(fields, "type")
+	}
+	if o.NotNull != n.NotNull {
+		fields = append(fields, "not_null")
+	}
+	if o.Unsigned != n.Unsigned {
+		fields = append(fields, "unsigned")
+	}
+	if o.AutoIncrement != n.AutoIncrement {
+		fields = append(fields, "auto_increment")
+	}
+	if !strings.EqualFold(o.Charset, n.Charset) {
+		fields = append(fields, "charset")
+	}
+	if !strings.EqualFold(o.Collate, n.Collate) {
+		fields = append(fields, "collate")
+	}
+	od, ook := columnDefault(o)
+	nd, nok := columnDefault(n)
+	if od != nd || ook != nok {
+		fields = append(fields, "default")
+	}
+	if !strings.EqualFold(o.OnUpdate, n.OnUpdate) {
+		fields = append(fields, "on_update")
+	}
+	if o.Comment != n.Comment {
+		fields = append(fields, "comment")
+	}
+	return fields
+}
+
+// columnDefault the default value as string, DEFAULT NULL same as no default
+func columnDefault(c *Column) (string, bool) {
+	if c.Default == nil {
+		return "", false
+	}
+	def := fmt.Sprint(c.Default)
+	if strings.EqualFold(def, "NULL") {
+		return "", false
+	}
+	return def, true
+}
+
+// columnPosition the position of column in schema, FIRST or AFTER the previous one
+func columnPosition(s *Schema, name string) string {
+	idx := s.columnIndex(name)
+	if idx == 0 {
+		return " FIRST"
+	}
+	if idx > 0 {
+		return " AFTER " + quoteIdent(s.Columns[idx-1].Name)
+	}
+	return ""
+}
+
+// findKey the key of the same name, or primary key, the foreign keys are named separately from the indexes,
+// the unnamed key is matched by type and columns
+func findKey(keys []*Key, key *Key) *Key {
+	kind := keyKind(key.IndexType)
+	for _, k := range keys {
+		switch {
+		case kind == "PRIMARY KEY":
+			if keyKind(k.IndexType) == kind {
+				return k
+			}
+		case key.Name == "":
+			if k.Name == "" && keyKind(k.IndexType) == kind && strings.EqualFold(strings.Join(k.Columns, ","), strings.Join(key.Columns, ",")) {
+				return k
+			}
+		case strings.EqualFold(k.Name, key.Name) && isForeignKey(k) == isForeignKey(key):
+			return k
+		}
+	}
+	return nil
+}
+
+// keyKind normalized key type, PRIMARY KEY, UNIQUE KEY, FULLTEXT KEY, SPATIAL KEY, FOREIGN KEY or KEY
+func keyKind(indexType string) string {
+	t := strings.ToUpper(indexType)
+	switch {
+	case strings.HasPrefix(t, "PRIMARY"):
+		return "PRIMARY KEY"
+	case strings.HasPrefix(t, "UNIQUE"):
+		return "UNIQUE KEY"
+	case strings.HasPrefix(t, "FULLTEXT"):
+		return "FULLTEXT KEY"
+	case strings.HasPrefix(t, "SPATIAL"):
+		return "SPATIAL KEY"
+	case strings.HasPrefix(t, "FOREIGN"):
+		return "FOREIGN KEY"
+	}
+	return "KEY"
+}
+
+// isForeignKey whether the key is a foreign key
+func isForeignKey(k *Key) bool {
+	return keyKind(k.IndexType) == "FOREIGN KEY"
+}
+
+// dropKeySQL the DROP clause of key in schema
+func dropKeySQL(s *Schema, k *Key) string {
+	switch keyKind(k.IndexType) {
+	case "PRIMARY KEY":
+		return "DROP PRIMARY KEY"
+	case "FOREIGN KEY":
+		return "DROP FOREIGN KEY " + quoteIdent(s.keyName(k))
+	}
+	return "DROP INDEX " + quoteIdent(s.keyName(k))
+}
+
+// keyName the name of key, or the name generated by MySQL for the unnamed key:
+// the first column with suffix _2, _3... if taken for index, <table>_ibfk_N for foreign key
+func (s *Schema) keyName(key *Key) string {
+	if key.Name != "" || keyKind(key.IndexType) == "PRIMARY KEY" {
+		return key.Name
+	}
+
+	if isForeignKey(key) {
+		var n int
+		for _, k := range s.Keys {
+			if isForeignKey(k) && k.Name == "" {
+				n++
+			}
+			if k == key {
+				return fmt.Sprintf("%s_ibfk_%d", s.TableName, n)
+			}
+		}
+		return ""
+	}
+
+	var used = map[string]bool{"primary": true}
+	for _, k := range s.Keys {
+		if k.Name != "" && !isForeignKey(k) {
+			used[strings.ToLower(k.Name)] = true
+		}
+	}
+	for _, k := range s.Keys {
+		if k.Name != "" || isForeignKey(k) || keyKind(k.IndexType) == "PRIMARY KEY" || len(k.Columns) == 0 {
+			continue
+		}
+		name := k.Columns[0]
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			name = fmt.Sprintf("%s_%d", k.Columns[0], i)
+		}
+		used[strings.ToLower(name)] = true
+		if k == key {
+			return name
+		}
+	}
+	return ""
+}
+
+// quoteIdent quote the MySQL identifier by backtick
+func quoteIdent(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// quoteString quote the MySQL string literal
+func quoteString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,315 @@
+package gormer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffStatements(t *testing.T) {
+	const user = "CREATE TABLE `user` (\n" +
+		"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+		"  `email` varchar(100) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_name` (`name`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'"
+	const order = "CREATE TABLE `order` (\n" +
+		"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` int(11) NOT NULL,\n" +
+		"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `fk_user` (`user_id`),\n" +
+		"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE\n" +
+		") ENGINE=InnoDB"
+	const unnamed = "CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `name` varchar(10) NOT NULL,\n" +
+		"  KEY (`name`),\n" +
+		"  UNIQUE KEY (`id`),\n" +
+		"  KEY (`name`, `id`),\n" +
+		"  FOREIGN KEY (`id`) REFERENCES `user` (`id`)\n" +
+		")"
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		opts DiffOptions
+		want []string
+	}{
+		{
+			name: "same",
+			from: user,
+			to:   user,
+		},
+		{
+			name: "add column",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `uid` bigint(20) NOT NULL,\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `age` tinyint(4) NOT NULL DEFAULT '0',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'",
+			want: []string{
+				"ALTER TABLE `user` ADD COLUMN `uid` bigint(20) NOT NULL FIRST",
+				"ALTER TABLE `user` ADD COLUMN `age` tinyint(4) NOT NULL DEFAULT '0' AFTER `name`",
+			},
+		},
+		{
+			name: "modify and drop column",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(100) NOT NULL DEFAULT 'guest' COMMENT 'it''s name',\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'",
+			want: []string{
+				"ALTER TABLE `user` MODIFY COLUMN `name` varchar(100) NOT NULL DEFAULT 'guest' COMMENT 'it''s name'",
+				"ALTER TABLE `user` DROP COLUMN `email`",
+			},
+		},
+		{
+			name: "skip destructive",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'",
+			opts: DiffOptions{SkipDestructive: true},
+		},
+		{
+			name: "keys",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  UNIQUE KEY `idx_name` (`name`, `email`),\n" +
+				"  UNIQUE KEY `uk_email` (`email`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'",
+			want: []string{
+				"ALTER TABLE `user` DROP INDEX `idx_name`",
+				"ALTER TABLE `user` ADD UNIQUE KEY `idx_name` (`name`, `email`)",
+				"ALTER TABLE `user` ADD UNIQUE KEY `uk_email` (`email`)",
+			},
+		},
+		{
+			name: "same unnamed keys",
+			from: unnamed,
+			to:   unnamed,
+		},
+		{
+			name: "drop unnamed keys",
+			from: unnamed,
+			to: "CREATE TABLE `t` (\n" +
+				"  `id` int(11) NOT NULL,\n" +
+				"  `name` varchar(10) NOT NULL,\n" +
+				"  KEY (`name`)\n" +
+				")",
+			want: []string{
+				"ALTER TABLE `t` DROP FOREIGN KEY `t_ibfk_1`",
+				"ALTER TABLE `t` DROP INDEX `id`",
+				"ALTER TABLE `t` DROP INDEX `name_2`",
+			},
+		},
+		{
+			name: "add unnamed keys",
+			from: "CREATE TABLE `t` (\n" +
+				"  `id` int(11) NOT NULL,\n" +
+				"  `name` varchar(10) NOT NULL,\n" +
+				"  KEY (`name`)\n" +
+				")",
+			to: unnamed,
+			want: []string{
+				"ALTER TABLE `t` ADD UNIQUE KEY (`id`)",
+				"ALTER TABLE `t` ADD KEY (`name`, `id`)",
+				"ALTER TABLE `t` ADD FOREIGN KEY (`id`) REFERENCES `user` (`id`)",
+			},
+		},
+		{
+			name: "options and rename",
+			from: user,
+			to: "CREATE TABLE `member` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COMMENT='member'",
+			want: []string{
+				"ALTER TABLE `user` ENGINE=MyISAM",
+				"ALTER TABLE `user` COMMENT='member'",
+				"ALTER TABLE `user` RENAME TO `member`",
+			},
+		},
+		{
+			name: "unspecified options",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") COMMENT='user'",
+		},
+		{
+			name: "unspecified options down",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") COMMENT='user'",
+			opts: DiffOptions{Down: true},
+		},
+		{
+			name: "on update",
+			from: order,
+			to: "CREATE TABLE `order` (\n" +
+				"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_id` int(11) NOT NULL,\n" +
+				"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `fk_user` (`user_id`),\n" +
+				"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE\n" +
+				") ENGINE=InnoDB",
+			want: []string{
+				"ALTER TABLE `order` MODIFY COLUMN `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP()",
+			},
+		},
+		{
+			name: "drop foreign key",
+			from: order,
+			to: "CREATE TABLE `order` (\n" +
+				"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_id` int(11) NOT NULL,\n" +
+				"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				") ENGINE=InnoDB",
+			want: []string{
+				"ALTER TABLE `order` DROP FOREIGN KEY `fk_user`",
+				"ALTER TABLE `order` DROP INDEX `fk_user`",
+			},
+		},
+		{
+			name: "add foreign key",
+			from: "CREATE TABLE `order` (\n" +
+				"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_id` int(11) NOT NULL,\n" +
+				"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				") ENGINE=InnoDB",
+			to: order,
+			want: []string{
+				"ALTER TABLE `order` ADD KEY `fk_user` (`user_id`)",
+				"ALTER TABLE `order` ADD CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE",
+			},
+		},
+		{
+			name: "change foreign key reference",
+			from: order,
+			to: "CREATE TABLE `order` (\n" +
+				"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+				"  `user_id` int(11) NOT NULL,\n" +
+				"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `fk_user` (`user_id`),\n" +
+				"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `member` (`id`) ON DELETE SET NULL ON UPDATE CASCADE\n" +
+				") ENGINE=InnoDB",
+			want: []string{
+				"ALTER TABLE `order` DROP FOREIGN KEY `fk_user`",
+				"ALTER TABLE `order` ADD CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `member` (`id`) ON DELETE SET NULL ON UPDATE CASCADE",
+			},
+		},
+		{
+			name: "down",
+			from: user,
+			to: "CREATE TABLE `user` (\n" +
+				"  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` varchar(50) NOT NULL DEFAULT '' COMMENT 'name',\n" +
+				"  `email` varchar(100) DEFAULT NULL,\n" +
+				"  `age` tinyint NOT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_name` (`name`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='user'",
+			opts: DiffOptions{Down: true},
+			want: []string{
+				"ALTER TABLE `user` DROP COLUMN `age`",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := parseSchema(t, tt.from), parseSchema(t, tt.to)
+			got := Diff(from, to).Statements(tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Statements() = %q, want %q", got, tt.want)
+			}
+			if tt.opts != (DiffOptions{}) {
+				return
+			}
+
+			// the statements migrate from to to
+			for _, stmt := range got {
+				if err := from.Apply(stmt); err != nil {
+					t.Fatalf("Apply() error = %v", err)
+				}
+			}
+			if d := Diff(from, to); !d.Empty() {
+				t.Errorf("Diff() after Apply = %q, want empty", d.Statements(DiffOptions{}))
+			}
+		})
+	}
+}
+
+func TestDiffForeignKeyName(t *testing.T) {
+	// the foreign key and the index of the same name are different keys
+	s := parseSchema(t, "CREATE TABLE `order` (\n"+
+		"  `user_id` int(11) NOT NULL,\n"+
+		"  KEY `fk_user` (`user_id`),\n"+
+		"  CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`)\n"+
+		")")
+	if err := s.Apply("ALTER TABLE `order` DROP FOREIGN KEY `fk_user`"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(s.Keys) != 1 || isForeignKey(s.Keys[0]) {
+		t.Fatalf("Keys = %v, want the index only", s.Keys)
+	}
+	if err := s.Apply("ALTER TABLE `order` ADD CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`)"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := "CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`)"; s.Keys[1].Definition() != want {
+		t.Errorf("Definition() = %s, want %s", s.Keys[1].Definition(), want)
+	}
+}
+
+func TestDiffUnnamedKey(t *testing.T) {
+	s := parseSchema(t, "CREATE TABLE t (id int, name varchar(10), KEY (name))")
+	if d := Diff(s, s); !d.Empty() {
+		t.Errorf("Diff() = %q, want empty", d.Statements(DiffOptions{}))
+	}
+	if got, want := s.Keys[0].Definition(), "KEY (`name`)"; got != want {
+		t.Errorf("Definition() = %s, want %s", got, want)
+	}
+}
+
+func parseSchema(t *testing.T, ddl string) *Schema {
+	t.Helper()
+	var s = &Schema{RawDDL: ddl}
+	if err := s.Parse(); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return s
+}
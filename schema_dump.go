@@ -0,0 +1,318 @@
+package gormer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/tidbparser/ast"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/parser"
+)
+
+var regexpDumpCreateTable, _ = regexp.Compile(`(?is)^CREATE\s+(TEMPORARY\s+)?TABLE\s+(IF\s+NOT\s+EXISTS\s+)?`)
+var regexpDumpCreateView, _ = regexp.Compile("(?is)^CREATE\\s+(OR\\s+REPLACE\\s+)?(.*?\\s+)?VIEW\\s+((?:`[^`]+`|\\w+)(?:\\.(?:`[^`]+`|\\w+))?)\\s*(\\([^)]*\\))?\\s+AS\\s+(.+)$")
+var regexpDumpDropTable, _ = regexp.Compile(`(?is)^DROP\s+(TEMPORARY\s+)?TABLE\s+(IF\s+EXISTS\s+)?(.+)$`)
+var regexpDumpDropView, _ = regexp.Compile(`(?is)^DROP\s+VIEW\s+(IF\s+EXISTS\s+)?(.+)$`)
+var regexpDumpAlterTable, _ = regexp.Compile("(?is)^ALTER\\s+TABLE\\s+((?:`[^`]+`|\\w+)(?:\\.(?:`[^`]+`|\\w+))?)\\s+(.*)$")
+var regexpDumpRenameTable, _ = regexp.Compile(`(?is)^RENAME\s+TABLE\s+`)
+var regexpDumpKeys, _ = regexp.Compile(`(?i)^(DISABLE|ENABLE)\s+KEYS$`)
+var regexpDumpIgnored, _ = regexp.Compile(`(?i)^(SET|LOCK|UNLOCK|INSERT|REPLACE|USE|CREATE\s+(DATABASE|SCHEMA)|START|BEGIN|COMMIT)\b`)
+var regexpDumpName, _ = regexp.Compile("(?:`([^`]+)`|(\\w+))\\s*$")
+var regexpDumpDelimiter, _ = regexp.Compile(`(?i)^DELIMITER\s+(\S+)`)
+
+// Database the tables and views of a SQL dump
+type Database struct {
+	Tables   map[string]*Schema `json:"tables"`
+	Views    map[string]*View   `json:"views"`
+	Warnings []DumpWarning      `json:"warnings"`
+}
+
+// View view info
+type View struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"` // the SELECT statement
+}
+
+// DumpWarning the statement is skipped
+type DumpWarning struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// String format as "line N: message"
+func (w DumpWarning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// DumpError parsing the statement of dump error
+type DumpError struct {
+	Line int // start line of the statement
+	Err  error
+}
+
+// Error implements error
+func (e *DumpError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap return the underlying error
+func (e *DumpError) Unwrap() error {
+	return e.Err
+}
+
+// dumpStmt a statement of dump with the start line
+type dumpStmt struct {
+	Line int
+	SQL  string
+}
+
+// ParseDump parse the mysqldump-style file, multiple statements separated by ";" or DELIMITER,
+// the comments are dropped, the executable comments(/*!40101 ... */) are unwrapped,
+// CREATE TABLE and CREATE VIEW are collected, DROP TABLE/VIEW removes the previous one,
+// ALTER TABLE is applied to the table by Schema.Apply, RENAME TABLE and ALTER TABLE ... RENAME TO re-key the table,
+// SET/LOCK/INSERT... are ignored, the other statements and the unsupported ALTER are skipped with warnings
+func ParseDump(r io.Reader) (*Database, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var db = &Database{
+		Tables: make(map[string]*Schema),
+		Views:  make(map[string]*View),
+	}
+	for _, stmt := range splitDump(string(content)) {
+		err := db.apply(stmt)
+		if errors.Is(err, ErrUnsupportedAlter) {
+			db.Warnings = append(db.Warnings, DumpWarning{Line: stmt.Line, Message: err.Error()})
+			continue
+		}
+		if err != nil {
+			return db, &DumpError{Line: stmt.Line, Err: err}
+		}
+	}
+	return db, nil
+}
+
+// TableNames the sorted table names
+func (d *Database) TableNames() []string {
+	var names = make([]string, 0, len(d.Tables))
+	for name := range d.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply the statement on the database
+func (d *Database) apply(stmt dumpStmt) error {
+	sql := stmt.SQL
+	switch {
+	case regexpDumpCreateTable.MatchString(sql):
+		var s = &Schema{RawDDL: sql}
+		if err := s.Parse(); err != nil {
+			return err
+		}
+		d.Tables[s.TableName] = s
+	case regexpDumpCreateView.MatchString(sql):
+		m := regexpDumpCreateView.FindStringSubmatch(sql)
+		name := unquoteName(m[3])
+		d.Views[name] = &View{Name: name, Definition: strings.TrimSpace(m[5])}
+	case regexpDumpDropTable.MatchString(sql):
+		for _, name := range strings.Split(regexpDumpDropTable.FindStringSubmatch(sql)[3], ",") {
+			delete(d.Tables, unquoteName(name))
+		}
+	case regexpDumpDropView.MatchString(sql):
+		for _, name := range strings.Split(regexpDumpDropView.FindStringSubmatch(sql)[2], ",") {
+			delete(d.Views, unquoteName(name))
+		}
+	case regexpDumpAlterTable.MatchString(sql):
+		m := regexpDumpAlterTable.FindStringSubmatch(sql)
+		if regexpDumpKeys.MatchString(strings.TrimSpace(m[2])) {
+			return nil
+		}
+		name := unquoteName(m[1])
+		s, ok := d.Tables[name]
+		if !ok {
+			return fmt.Errorf("table %s not exists", m[1])
+		}
+		err := s.Apply(sql)
+		// RENAME TO, the statements before the failed one are applied
+		if s.TableName != name {
+			if rerr := d.renameTable(name, s.TableName); rerr != nil {
+				s.TableName = name
+				return rerr
+			}
+		}
+		return err
+	case regexpDumpRenameTable.MatchString(sql):
+		stmts, err := parser.New().Parse(sql, "", "")
+		if err != nil {
+			return err
+		}
+		for _, stmt := range stmts {
+			rename, ok := stmt.(*ast.RenameTableStmt)
+			if !ok {
+				return ErrUnsupportedAlter
+			}
+			// renamed in order, e.g. RENAME TABLE a TO tmp, b TO a, tmp TO b
+			for _, t := range rename.TableToTables {
+				if err = d.renameTable(t.OldTable.Name.String(), t.NewTable.Name.String()); err != nil {
+					return err
+				}
+			}
+		}
+	case regexpDumpIgnored.MatchString(sql):
+	default:
+		d.Warnings = append(d.Warnings, DumpWarning{Line: stmt.Line, Message: "unsupported statement: " + dumpPreview(sql)})
+	}
+	return nil
+}
+
+// renameTable re-key the table, and update the TableName
+func (d *Database) renameTable(from, to string) error {
+	s, ok := d.Tables[from]
+	if !ok {
+		return fmt.Errorf("table %s not exists", from)
+	}
+	if _, ok = d.Tables[to]; ok && from != to {
+		return fmt.Errorf("table %s already exists", to)
+	}
+	delete(d.Tables, from)
+	s.TableName = to
+	d.Tables[to] = s
+	return nil
+}
+
+// unquoteName the name without quote and database, e.g. "`db`.`user`" -> "user"
+func unquoteName(name string) string {
+	m := regexpDumpName.FindStringSubmatch(name)
+	if m == nil {
+		return strings.TrimSpace(name)
+	}
+	return m[1] + m[2]
+}
+
+// dumpPreview the statement in one line, truncated
+func dumpPreview(sql string) string {
+	sql = regexpSQLSpace.ReplaceAllString(sql, " ")
+	if len(sql) > ScanPreviewSize {
+		sql = sql[:ScanPreviewSize] + "..."
+	}
+	return sql
+}
+
+// splitDump split the content to statements,
+// the quoted strings and identifiers are kept, the comments are dropped, the executable comments are unwrapped
+func splitDump(content string) []dumpStmt {
+	var (
+		stmts     []dumpStmt
+		buf       strings.Builder
+		delimiter = ";"
+		line      = 1
+		start     = 0 // start line of the current statement
+	)
+
+	flush := func() {
+		if sql := strings.TrimSpace(buf.String()); sql != "" {
+			stmts = append(stmts, dumpStmt{Line: start, SQL: sql})
+		}
+		buf.Reset()
+		start = 0
+	}
+	write := func(s string) {
+		if start == 0 && strings.TrimSpace(s) != "" {
+			start = line
+		}
+		buf.WriteString(s)
+		line += strings.Count(s, "\n")
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		rest := content[i:]
+
+		switch {
+		// DELIMITER command, at the beginning of line
+		case start == 0 && (i == 0 || content[i-1] == '\n') && regexpDumpDelimiter.MatchString(rest):
+			delimiter = regexpDumpDelimiter.FindStringSubmatch(rest)[1]
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+
+		// the end of statement
+		case strings.HasPrefix(rest, delimiter):
+			flush()
+			i += len(delimiter)
+
+		// quoted string or identifier
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			for j < len(content) {
+				if content[j] == '\\' && c != '`' {
+					j += 2
+					continue
+				}
+				if content[j] == c {
+					// doubled quote as escape
+					if j+1 < len(content) && content[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j >= len(content) {
+				j = len(content) - 1
+			}
+			write(content[i : j+1])
+			i = j + 1
+
+		// line comment
+		// "--" must be followed by a whitespace or the end, include "\r" of CRLF
+		case c == '#' || strings.HasPrefix(rest, "--") && (len(rest) == 2 || strings.IndexByte(" \t\r\n\f\v", rest[2]) >= 0):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+
+		// executable comment, unwrap the content after the version
+		case strings.HasPrefix(rest, "/*!"):
+			j := i + 3
+			for j < len(content) && content[j] >= '0' && content[j] <= '9' {
+				j++
+			}
+			end := strings.Index(content[j:], "*/")
+			if end < 0 {
+				end = len(content) - j
+			}
+			// the delimiter inside is not a separator, e.g. /*!50003 ... ; ... */
+			write(" " + content[j:j+end] + " ")
+			i = j + end + 2
+
+		// block comment
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest, "*/")
+			if end < 0 {
+				end = len(rest) - 2
+			}
+			line += strings.Count(rest[:end+2], "\n")
+			i += end + 2
+
+		default:
+			write(string(c))
+			i++
+		}
+	}
+	flush()
+
+	return stmts
+}
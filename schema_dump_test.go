@@ -0,0 +1,192 @@
+package gormer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitDump(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []dumpStmt
+	}{
+		{
+			name:    "statements",
+			content: "SET NAMES utf8;\nCREATE TABLE a (id int);\n\nDROP TABLE b",
+			want: []dumpStmt{
+				{Line: 1, SQL: "SET NAMES utf8"},
+				{Line: 2, SQL: "CREATE TABLE a (id int)"},
+				{Line: 4, SQL: "DROP TABLE b"},
+			},
+		},
+		{
+			name:    "line comments",
+			content: "-- comment; x\n# hash; x\n--\nSELECT 1; -- tail\n--\tSELECT 2;\n--",
+			want:    []dumpStmt{{Line: 4, SQL: "SELECT 1"}},
+		},
+		{
+			name:    "line comments of CRLF",
+			content: "--\r\n-- Table structure; x\r\n--\r\nSELECT 1;\r\n",
+			want:    []dumpStmt{{Line: 4, SQL: "SELECT 1"}},
+		},
+		{
+			name:    "double dash without whitespace is not comment",
+			content: "SELECT 1--1;",
+			want:    []dumpStmt{{Line: 1, SQL: "SELECT 1--1"}},
+		},
+		{
+			name:    "quoted",
+			content: "INSERT INTO a VALUES ('x;y', 'it''s', 'a\\';b', \"-- no\");\nSELECT `a;b`;",
+			want: []dumpStmt{
+				{Line: 1, SQL: "INSERT INTO a VALUES ('x;y', 'it''s', 'a\\';b', \"-- no\")"},
+				{Line: 2, SQL: "SELECT `a;b`"},
+			},
+		},
+		{
+			name:    "block and executable comments",
+			content: "/* block;\ncomment */\n/*!40101 SET NAMES utf8 */;\nSELECT /* x; */ 1;",
+			want: []dumpStmt{
+				{Line: 3, SQL: "SET NAMES utf8"},
+				{Line: 4, SQL: "SELECT  1"},
+			},
+		},
+		{
+			name:    "delimiter",
+			content: "DELIMITER ;;\nCREATE TRIGGER t BEGIN SET x = 1; END;;\nDELIMITER ;\nSELECT 1;",
+			want: []dumpStmt{
+				{Line: 2, SQL: "CREATE TRIGGER t BEGIN SET x = 1; END"},
+				{Line: 4, SQL: "SELECT 1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitDump(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitDump() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDump(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		tables   []string
+		columns  map[string][]string
+		views    []string
+		warnings int
+		wantErr  bool
+	}{
+		{
+			name: "mysqldump",
+			content: "--\r\n-- Table structure for table `user`\r\n--\r\n" +
+				"/*!40101 SET @saved_cs_client = @@character_set_client */;\r\n" +
+				"DROP TABLE IF EXISTS `user`;\r\n" +
+				"CREATE TABLE `user` (\r\n  `id` int NOT NULL AUTO_INCREMENT,\r\n  PRIMARY KEY (`id`)\r\n) ENGINE=InnoDB;\r\n" +
+				"LOCK TABLES `user` WRITE;\r\n" +
+				"/*!40000 ALTER TABLE `user` DISABLE KEYS */;\r\n" +
+				"INSERT INTO `user` VALUES (1);\r\n" +
+				"/*!40000 ALTER TABLE `user` ENABLE KEYS */;\r\n" +
+				"UNLOCK TABLES;\r\n" +
+				"CREATE ALGORITHM=UNDEFINED VIEW `v_user` AS SELECT `id` FROM `user`;\r\n",
+			tables:  []string{"user"},
+			columns: map[string][]string{"user": {"id"}},
+			views:   []string{"v_user"},
+		},
+		{
+			name: "alter",
+			content: "CREATE TABLE a (id int);\n" +
+				"ALTER TABLE a ADD COLUMN name varchar(50);\n" +
+				"ALTER TABLE `a` DROP COLUMN name, ADD COLUMN email varchar(50);",
+			tables:  []string{"a"},
+			columns: map[string][]string{"a": {"id", "email"}},
+		},
+		{
+			name: "alter rename",
+			content: "CREATE TABLE a (id int);\n" +
+				"ALTER TABLE a RENAME TO b;\n" +
+				"ALTER TABLE b ADD COLUMN x int;",
+			tables:  []string{"b"},
+			columns: map[string][]string{"b": {"id", "x"}},
+		},
+		{
+			name: "rename table",
+			content: "CREATE TABLE a (id int);\nCREATE TABLE b (name varchar(50));\n" +
+				"RENAME TABLE a TO tmp, b TO a, tmp TO b;\n" +
+				"ALTER TABLE a ADD COLUMN x int;",
+			tables:  []string{"a", "b"},
+			columns: map[string][]string{"a": {"name", "x"}, "b": {"id"}},
+		},
+		{
+			name:    "rename table exists",
+			content: "CREATE TABLE a (id int);\nCREATE TABLE b (id int);\nRENAME TABLE a TO b;",
+			wantErr: true,
+		},
+		{
+			name:    "drop",
+			content: "CREATE TABLE a (id int);\nCREATE TABLE b (id int);\nCREATE VIEW v AS SELECT 1;\nDROP TABLE a, `b`;\nDROP VIEW v;",
+		},
+		{
+			name:     "unsupported",
+			content:  "CREATE TABLE a (id int);\nCREATE PROCEDURE p() SELECT 1;\nGRANT ALL ON *.* TO u;",
+			tables:   []string{"a"},
+			columns:  map[string][]string{"a": {"id"}},
+			warnings: 2,
+		},
+		{
+			name: "alter lock",
+			content: "CREATE TABLE a (id int);\n" +
+				"ALTER TABLE a ADD COLUMN age int, LOCK=NONE;\n" +
+				"CREATE TABLE b (id int);",
+			tables:  []string{"a", "b"},
+			columns: map[string][]string{"a": {"id", "age"}, "b": {"id"}},
+		},
+		{
+			name:    "alter not exists",
+			content: "ALTER TABLE a ADD COLUMN x int;",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDump(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDump() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := d.TableNames(); !reflect.DeepEqual(got, append([]string{}, tt.tables...)) {
+				t.Errorf("TableNames() = %v, want %v", got, tt.tables)
+			}
+			for table, want := range tt.columns {
+				var got []string
+				for _, c := range d.Tables[table].Columns {
+					got = append(got, c.Name)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("columns of %s = %v, want %v", table, got, want)
+				}
+				if d.Tables[table].TableName != table {
+					t.Errorf("TableName = %s, want %s", d.Tables[table].TableName, table)
+				}
+			}
+			var views []string
+			for name := range d.Views {
+				views = append(views, name)
+			}
+			if !reflect.DeepEqual(views, tt.views) {
+				t.Errorf("views = %v, want %v", views, tt.views)
+			}
+			if len(d.Warnings) != tt.warnings {
+				t.Errorf("warnings = %v, want %d", d.Warnings, tt.warnings)
+			}
+		})
+	}
+}
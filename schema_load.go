@@ -0,0 +1,406 @@
+package gormer
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/sqlparser"
+	"github.com/jinzhu/gorm"
+)
+
+var regexpPgDefault, _ = regexp.Compile(`(?s)^'(.*)'::[\w\s."]+$`)
+var regexpSqliteAutoIncrement, _ = regexp.Compile(`(?i)\bAUTOINCREMENT\b`)
+
+// pgReferOptions the referential actions of pg_constraint
+var pgReferOptions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// LoadSchema introspect the table schema of live database,
+// SHOW CREATE TABLE and Parse on MySQL, pg_catalog on PostgreSQL, sqlite_master and PRAGMA on SQLite,
+// RawDDL is the CREATE TABLE statement on MySQL and SQLite, empty on PostgreSQL,
+// the columns, keys(with foreign keys) are loaded on all dialects, but not filled:
+// Engine, AutoIncrement, the charset, collation and ON UPDATE of column on PostgreSQL and SQLite,
+// the charset, collation and comments of table, and the comments of column on SQLite, the foreign key is unnamed on SQLite
+func LoadSchema(db *gorm.DB, table string) (*Schema, error) {
+	switch db.Dialect().GetName() {
+	case "mysql":
+		return loadMysqlSchema(db, table)
+	case "postgres":
+		return loadPostgresSchema(db, table)
+	case "sqlite3":
+		return loadSqliteSchema(db, table)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialect().GetName())
+}
+
+// LoadDatabase introspect all the tables of current database/schema by LoadSchema, the views are not loaded
+func LoadDatabase(db *gorm.DB) (*Database, error) {
+	var query string
+	switch db.Dialect().GetName() {
+	case "mysql":
+		query = "SELECT TABLE_NAME AS name FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'"
+	case "postgres":
+		query = "SELECT tablename AS name FROM pg_catalog.pg_tables WHERE schemaname = current_schema()"
+	case "sqlite3":
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialect().GetName())
+	}
+
+	var names []string
+	if err := db.Raw(query).Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var d = &Database{
+		Tables: make(map[string]*Schema, len(names)),
+		Views:  make(map[string]*View),
+	}
+	for _, name := range names {
+		s, err := LoadSchema(db, name)
+		if err != nil {
+			return nil, err
+		}
+		d.Tables[s.TableName] = s
+	}
+	return d, nil
+}
+
+// loadMysqlSchema parse the result of SHOW CREATE TABLE
+func loadMysqlSchema(db *gorm.DB, table string) (*Schema, error) {
+	rows, err := db.Raw("SHOW CREATE TABLE " + quoteIdent(table)).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) != 2 {
+		return nil, fmt.Errorf("%s is not a base table", table)
+	}
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: table %s", gorm.ErrRecordNotFound, table)
+	}
+
+	var name string
+	var s = new(Schema)
+	if err = rows.Scan(&name, &s.RawDDL); err != nil {
+		return nil, err
+	}
+	if err = s.Parse(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadPostgresSchema query the columns, indexes and comments from pg_catalog
+func loadPostgresSchema(db *gorm.DB, table string) (*Schema, error) {
+	var s = &Schema{TableName: table}
+
+	// table comment, charset and collation
+	var comment, charset, collation sql.NullString
+	err := db.Raw(`SELECT obj_description(c.oid, 'pg_class'), d.charset, d.collation
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace,
+		(SELECT pg_encoding_to_char(encoding) AS charset, datcollate::text AS collation
+			FROM pg_database WHERE datname = current_database()) d
+		WHERE c.relname = ? AND n.nspname = current_schema() AND c.relkind IN ('r', 'p')`, table).
+		Row().Scan(&comment, &charset, &collation)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: table %s", gorm.ErrRecordNotFound, table)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Comment = comment.String
+	s.DefaultCharset = charset.String
+	s.DefaultCollation = collation.String
+
+	// columns
+	rows, err := db.Raw(`SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+		pg_get_expr(d.adbin, d.adrelid), col_description(a.attrelid, a.attnum), a.attidentity <> ''
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE c.relname = ? AND n.nspname = current_schema() AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, tp string
+		var notNull, identity bool
+		var def, colComment sql.NullString
+		if err = rows.Scan(&name, &tp, &notNull, &def, &colComment, &identity); err != nil {
+			return nil, err
+		}
+
+		var options []*sqlparser.ColumnOption
+		if notNull {
+			options = append(options, &sqlparser.ColumnOption{Type: sqlparser.ColumnOptionNotNull})
+		}
+		if identity || strings.HasPrefix(def.String, "nextval(") {
+			options = append(options, &sqlparser.ColumnOption{Type: sqlparser.ColumnOptionAutoIncrement})
+		} else if def.Valid {
+			value := def.String
+			if m := regexpPgDefault.FindStringSubmatch(value); m != nil {
+				value = strings.Replace(m[1], "''", "'", -1)
+			}
+			options = append(options, &sqlparser.ColumnOption{Type: sqlparser.ColumnOptionDefaultValue, Value: value})
+		}
+
+		col := newColumn(name, tp, options)
+		col.Comment = colComment.String
+		s.Columns = append(s.Columns, col)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// indexes, the columns in order
+	keys, err := db.Raw(`SELECT i.relname, ix.indisprimary, ix.indisunique, am.amname, a.attname
+		FROM pg_class t
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_index ix ON ix.indrelid = t.oid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_am am ON am.oid = i.relam
+		JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE t.relname = ? AND n.nspname = current_schema()
+		ORDER BY i.relname, k.ord`, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer keys.Close()
+
+	var last *Key
+	for keys.Next() {
+		var name, method, column string
+		var primary, unique bool
+		if err = keys.Scan(&name, &primary, &unique, &method, &column); err != nil {
+			return nil, err
+		}
+		if last == nil || last.Name != name {
+			last = &Key{Name: name, IndexType: keyIndexType(primary, unique), IndexMethod: method}
+			if primary {
+				last.Name = ""
+			}
+			s.Keys = append(s.Keys, last)
+		}
+		last.Columns = append(last.Columns, column)
+	}
+	if err = keys.Err(); err != nil {
+		return nil, err
+	}
+
+	// foreign keys, the columns in order
+	fks, err := db.Raw(`SELECT con.conname, a.attname, rc.relname, ra.attname, con.confupdtype::text, con.confdeltype::text
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_class rc ON rc.oid = con.confrelid
+		JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		JOIN pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.fattnum
+		WHERE con.contype = 'f' AND c.relname = ? AND n.nspname = current_schema()
+		ORDER BY con.conname, k.ord`, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer fks.Close()
+
+	last = nil
+	for fks.Next() {
+		var name, column, refTable, refColumn, onUpdate, onDelete string
+		if err = fks.Scan(&name, &column, &refTable, &refColumn, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+		if last == nil || last.Name != name {
+			last = &Key{Name: name, IndexType: sqlparser.ConstraintForeignKey.String(), Reference: &KeyReference{
+				Table:    refTable,
+				OnDelete: referOption(pgReferOptions[onDelete]),
+				OnUpdate: referOption(pgReferOptions[onUpdate]),
+			}}
+			s.Keys = append(s.Keys, last)
+		}
+		last.Columns = append(last.Columns, column)
+		last.Reference.Columns = append(last.Reference.Columns, refColumn)
+	}
+	return s, fks.Err()
+}
+
+// loadSqliteSchema query the columns and indexes by PRAGMA
+func loadSqliteSchema(db *gorm.DB, table string) (*Schema, error) {
+	var s = &Schema{TableName: table}
+	err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Row().Scan(&s.RawDDL)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: table %s", gorm.ErrRecordNotFound, table)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// columns
+	rows, err := db.Raw("SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?)", table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var primary = make(map[int]string)
+	var primaryType string
+	for rows.Next() {
+		var pk int
+		var name, tp string
+		var notNull bool
+		var def sql.NullString
+		if err = rows.Scan(&name, &tp, &notNull, &def, &pk); err != nil {
+			return nil, err
+		}
+
+		var options []*sqlparser.ColumnOption
+		if notNull {
+			options = append(options, &sqlparser.ColumnOption{Type: sqlparser.ColumnOptionNotNull})
+		}
+		if def.Valid {
+			value := def.String
+			if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+				value = strings.Replace(value[1:len(value)-1], "''", "'", -1)
+			}
+			options = append(options, &sqlparser.ColumnOption{Type: sqlparser.ColumnOptionDefaultValue, Value: value})
+		}
+		if pk > 0 {
+			primary[pk] = name
+			primaryType = tp
+		}
+		s.Columns = append(s.Columns, newColumn(name, strings.ToLower(tp), options))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(primary) > 0 {
+		var key = &Key{IndexType: sqlparser.ConstraintPrimaryKey.String()}
+		for i := 1; i <= len(primary); i++ {
+			key.Columns = append(key.Columns, primary[i])
+		}
+		s.Keys = append(s.Keys, key)
+
+		// INTEGER PRIMARY KEY is the alias of rowid
+		if len(primary) == 1 && (strings.EqualFold(primaryType, "integer") || regexpSqliteAutoIncrement.MatchString(s.RawDDL)) {
+			s.Columns[s.columnIndex(primary[1])].AutoIncrement = true
+		}
+	}
+
+	// indexes, the primary key is loaded by table_info
+	list, err := db.Raw(`SELECT name, "unique" FROM pragma_index_list(?) WHERE origin <> 'pk' ORDER BY seq DESC`, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer list.Close()
+
+	first := len(s.Keys)
+	for list.Next() {
+		var name string
+		var unique bool
+		if err = list.Scan(&name, &unique); err != nil {
+			return nil, err
+		}
+		s.Keys = append(s.Keys, &Key{Name: name, IndexType: keyIndexType(false, unique)})
+	}
+	if err = list.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range s.Keys[first:] {
+		err = db.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", key.Name).Pluck("name", &key.Columns).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// foreign keys are unnamed, the id is in the reverse order of definition
+	fks, err := db.Raw(`SELECT id, "table", "from", "to", on_update, on_delete FROM pragma_foreign_key_list(?) ORDER BY id DESC, seq`, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer fks.Close()
+
+	first = len(s.Keys)
+	var last *Key
+	var lastID = -1
+	for fks.Next() {
+		var id int
+		var refTable, column, onUpdate, onDelete string
+		var refColumn sql.NullString
+		if err = fks.Scan(&id, &refTable, &column, &refColumn, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+		if last == nil || id != lastID {
+			last = &Key{IndexType: sqlparser.ConstraintForeignKey.String(), Reference: &KeyReference{
+				Table:    refTable,
+				OnDelete: referOption(onDelete),
+				OnUpdate: referOption(onUpdate),
+			}}
+			lastID = id
+			s.Keys = append(s.Keys, last)
+		}
+		last.Columns = append(last.Columns, column)
+		if refColumn.Valid {
+			last.Reference.Columns = append(last.Reference.Columns, refColumn.String)
+		}
+	}
+	if err = fks.Err(); err != nil {
+		return nil, err
+	}
+
+	// REFERENCES without columns is the primary key of referenced table
+	for _, key := range s.Keys[first:] {
+		if len(key.Reference.Columns) > 0 {
+			continue
+		}
+		err = db.Raw("SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk", key.Reference.Table).
+			Pluck("name", &key.Reference.Columns).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// referOption the referential action same as Parse, NO ACTION is the default, same as no clause
+func referOption(action string) string {
+	if strings.EqualFold(action, "NO ACTION") {
+		return ""
+	}
+	return strings.ToUpper(action)
+}
+
+// keyIndexType index type same as Parse
+func keyIndexType(primary, unique bool) string {
+	switch {
+	case primary:
+		return sqlparser.ConstraintPrimaryKey.String()
+	case unique:
+		return sqlparser.ConstraintUniq.String()
+	}
+	return sqlparser.ConstraintIndex.String()
+}
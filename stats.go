@@ -0,0 +1,226 @@
+package gormer
+
+import (
+	"math"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DefaultStatsSampleSize default samples kept per statement for percentiles
+const DefaultStatsSampleSize = 1024
+
+const statsStartKey = "gormer:stats_start"
+
+var (
+	regexpSQLString, _      = regexp.Compile(`'(?:[^'\\]|\\.|'')*'`)
+	regexpSQLNumber, _      = regexp.Compile(`\b\d+(?:\.\d+)?\b`)
+	regexpSQLPlaceholder, _ = regexp.Compile(`\$\d+`)
+	regexpSQLInList, _      = regexp.Compile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	regexpSQLSpace, _       = regexp.Compile(`\s+`)
+)
+
+// QueryStats slow query detection and statistics of SQL statements, by GORM callbacks
+type QueryStats struct {
+	Logger        StructuredLogger
+	SlowThreshold time.Duration // (optional) log the statement slower than it, 0 means no slow log
+	SampleSize    int           // (optional) samples kept per statement for percentiles, default DefaultStatsSampleSize
+
+	mu    sync.Mutex
+	stats map[string]*statementStats
+}
+
+// StatementStats statistics of the normalized statement
+type StatementStats struct {
+	SQL    string        `json:"sql"`
+	Count  int64         `json:"count"`
+	Errors int64         `json:"errors"`
+	Slow   int64         `json:"slow"`
+	Total  time.Duration `json:"total"`
+	Max    time.Duration `json:"max"`
+	P50    time.Duration `json:"p50"`
+	P99    time.Duration `json:"p99"`
+}
+
+// statementStats statistics with the samples of duration
+type statementStats struct {
+	StatementStats
+	samples []time.Duration
+	next    int
+}
+
+// NewQueryStats new QueryStats, log the slow statements by l
+func NewQueryStats(l Logger, slowThreshold time.Duration) *QueryStats {
+	if l == nil {
+		l = new(DefaultLogger)
+	}
+	return &QueryStats{
+		Logger:        Structured(l),
+		SlowThreshold: slowThreshold,
+		SampleSize:    DefaultStatsSampleSize,
+	}
+}
+
+// Register register the callbacks of create/query/update/delete/row_query to db
+func (s *QueryStats) Register(db *gorm.DB) {
+	cb := db.Callback()
+	cb.Create().Before("gorm:create").Register("gormer:stats_before_create", s.before)
+	cb.Create().After("gorm:create").Register("gormer:stats_after_create", s.after)
+	cb.Query().Before("gorm:query").Register("gormer:stats_before_query", s.before)
+	cb.Query().After("gorm:query").Register("gormer:stats_after_query", s.after)
+	cb.Update().Before("gorm:update").Register("gormer:stats_before_update", s.before)
+	cb.Update().After("gorm:update").Register("gormer:stats_after_update", s.after)
+	cb.Delete().Before("gorm:delete").Register("gormer:stats_before_delete", s.before)
+	cb.Delete().After("gorm:delete").Register("gormer:stats_after_delete", s.after)
+	cb.RowQuery().Before("gorm:row_query").Register("gormer:stats_before_row_query", s.before)
+	cb.RowQuery().After("gorm:row_query").Register("gormer:stats_after_row_query", s.after)
+}
+
+// Snapshot the statistics of statements, sorted by total duration desc
+func (s *QueryStats) Snapshot() []StatementStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list = make([]StatementStats, 0, len(s.stats))
+	for _, st := range s.stats {
+		item := st.StatementStats
+		samples := append([]time.Duration(nil), st.samples...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		item.P50 = percentile(samples, 0.50)
+		item.P99 = percentile(samples, 0.99)
+		list = append(list, item)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Total > list[j].Total })
+	return list
+}
+
+// Reset clear the statistics
+func (s *QueryStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = nil
+}
+
+// before record the start time
+func (s *QueryStats) before(scope *gorm.Scope) {
+	scope.InstanceSet(statsStartKey, time.Now())
+}
+
+// after collect the statement, log if slow
+func (s *QueryStats) after(scope *gorm.Scope) {
+	v, ok := scope.InstanceGet(statsStartKey)
+	if !ok || scope.SQL == "" {
+		return
+	}
+	elapsed := time.Since(v.(time.Time))
+
+	err := scope.DB().Error
+	if gorm.IsRecordNotFoundError(err) {
+		err = nil
+	}
+	slow := s.SlowThreshold > 0 && elapsed >= s.SlowThreshold
+	s.collect(NormalizeSQL(scope.SQL), elapsed, err != nil, slow)
+
+	if slow {
+		s.Logger.Log(LevelWarn, "slow query",
+			F("sql", scope.SQL),
+			F("vars", scope.SQLVars),
+			F("elapsed", elapsed),
+			F("rows", scope.DB().RowsAffected),
+			F("err", err),
+			F("caller", caller()),
+		)
+	}
+}
+
+// collect add the sample of statement
+func (s *QueryStats) collect(sql string, elapsed time.Duration, failed, slow bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stats == nil {
+		s.stats = make(map[string]*statementStats)
+	}
+	st, ok := s.stats[sql]
+	if !ok {
+		st = &statementStats{StatementStats: StatementStats{SQL: sql}}
+		s.stats[sql] = st
+	}
+
+	st.Count++
+	st.Total += elapsed
+	if elapsed > st.Max {
+		st.Max = elapsed
+	}
+	if failed {
+		st.Errors++
+	}
+	if slow {
+		st.Slow++
+	}
+
+	// ring buffer of samples
+	size := s.SampleSize
+	if size <= 0 {
+		size = DefaultStatsSampleSize
+	}
+	if len(st.samples) < size {
+		st.samples = append(st.samples, elapsed)
+	} else {
+		st.samples[st.next%len(st.samples)] = elapsed
+		st.next++
+	}
+}
+
+// NormalizeSQL normalize the statement for statistics,
+// literals and placeholders as ?, IN list as IN (?), collapse the spaces
+func NormalizeSQL(sql string) string {
+	sql = regexpSQLString.ReplaceAllString(sql, "?")
+	sql = regexpSQLPlaceholder.ReplaceAllString(sql, "?")
+	sql = regexpSQLNumber.ReplaceAllString(sql, "?")
+	sql = regexpSQLInList.ReplaceAllString(sql, "IN (?)")
+	sql = regexpSQLSpace.ReplaceAllString(sql, " ")
+	return strings.TrimSpace(sql)
+}
+
+// percentile of the sorted samples, nearest-rank
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(float64(len(sorted))*p)) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// gormerDir the source directory of gormer, skipped by caller
+var gormerDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// caller the file:line of the first caller outside of gorm and gormer
+func caller() string {
+	for i := 2; i < 20; i++ {
+		_, file, line, ok := runtime.Caller(i)
+		if !ok {
+			break
+		}
+		if strings.Contains(file, "jinzhu/gorm") ||
+			(filepath.Dir(file) == gormerDir && !strings.HasSuffix(file, "_test.go")) {
+			continue
+		}
+		return file + ":" + strconv.Itoa(line)
+	}
+	return ""
+}